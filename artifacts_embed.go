@@ -0,0 +1,22 @@
+//go:build embedartifacts
+
+package main
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Running `forge build` followed by `go build -tags embedartifacts` bakes the contract artifacts
+// into the binary, so it can be run without the repository or Foundry.
+//
+//go:embed out/*.sol/*.json
+var artifactFiles embed.FS
+
+func init() {
+	artifacts, err := fs.Sub(artifactFiles, "out")
+	if err != nil {
+		panic(err)
+	}
+	embeddedArtifacts = artifacts
+}
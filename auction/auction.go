@@ -0,0 +1,332 @@
+// Package auction provides a typed client for the SealedAuction contract deployed on SUAVE.
+package auction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"suave/sealedauction/framework"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Client wraps a deployed SealedAuction contract. Every method sends its request as the
+// account the underlying contract was created or referenced with.
+type Client struct {
+	contract *framework.Contract
+	decoder  *Decoder
+}
+
+// NewClient creates a client for the auction contract. The oracle ABI is needed to decode
+// the events the oracle emits during confidential execution (ErrorEvent, TxEvent, EncodedTx).
+func NewClient(contract *framework.Contract, oracleAbi *abi.ABI) *Client {
+	return &Client{contract: contract, decoder: NewDecoder(contract.Abi, oracleAbi)}
+}
+
+// Contract returns the underlying framework contract.
+func (c *Client) Contract() *framework.Contract {
+	return c.contract
+}
+
+// Address returns the address of the auction contract on SUAVE.
+func (c *Client) Address() common.Address {
+	return c.contract.Raw().Address()
+}
+
+// Ref returns a client for the same auction which sends its requests as acct.
+func (c *Client) Ref(acct *framework.PrivKey) *Client {
+	return &Client{contract: c.contract.Ref(acct), decoder: c.decoder}
+}
+
+// Decoder returns the decoder for the events of the auction and its oracle.
+func (c *Client) Decoder() *Decoder {
+	return c.decoder
+}
+
+// Transfers collects the L1 side effects reported by the oracle in a receipt.
+type Transfers struct {
+	// TxHashes of the L1 transactions broadcast by the oracle (TxEvent).
+	TxHashes []common.Hash
+	// SignedTxs are hex encoded L1 transactions signed but not broadcast by the oracle (EncodedTx).
+	SignedTxs []string
+	// Errors contains the messages of all ErrorEvent logs.
+	Errors []string
+}
+
+type SetUpResult struct {
+	Receipt           *types.Receipt
+	Events            []Event
+	NFTHoldingAddress common.Address
+}
+
+// SetUp generates the NFT holding address. Only the auctioneer may call it.
+func (c *Client) SetUp(ctx context.Context) (*SetUpResult, error) {
+	receipt, err := c.contract.SendConfidentialRequest(ctx, "setUpAuction", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("setting up auction: %w", err)
+	}
+	events, err := c.decoder.Decode(receipt)
+	if err != nil {
+		return nil, err
+	}
+	res := &SetUpResult{Receipt: receipt, Events: events}
+	for _, event := range events {
+		if e, ok := event.(*NFTHoldingAddressEvent); ok {
+			res.NFTHoldingAddress = e.NFTHoldingAddress
+		}
+	}
+	if res.NFTHoldingAddress == (common.Address{}) {
+		res.NFTHoldingAddress, err = c.NFTHoldingAddress(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+type StartResult struct {
+	Receipt            *types.Receipt
+	Events             []Event
+	ContractAddress    common.Address
+	NFTContractAddress common.Address
+	NFTTokenID         *big.Int
+	EndTimestamp       *big.Int
+	MinimalBid         *big.Int
+}
+
+// Start opens the auction once the NFT arrived at the holding address. Only the auctioneer may call it.
+func (c *Client) Start(ctx context.Context) (*StartResult, error) {
+	receipt, err := c.contract.SendConfidentialRequest(ctx, "startAuction", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting auction: %w", err)
+	}
+	events, err := c.decoder.Decode(receipt)
+	if err != nil {
+		return nil, err
+	}
+	res := &StartResult{Receipt: receipt, Events: events}
+	for _, event := range events {
+		if e, ok := event.(*AuctionOpened); ok {
+			res.ContractAddress = e.ContractAddr
+			res.NFTContractAddress = e.NFTContractAddress
+			res.NFTTokenID = e.NFTTokenID
+			res.EndTimestamp = e.EndTimestamp
+			res.MinimalBid = e.MinimalBiddingAmount
+		}
+	}
+	return res, nil
+}
+
+type BiddingAddress struct {
+	Receipt *types.Receipt
+	Events  []Event
+	// Owner is the SUAVE address the bidding address belongs to.
+	Owner common.Address
+	// Encrypted is the bidding address as emitted by the contract.
+	Encrypted []byte
+	// Address is the decrypted L1 bidding address.
+	Address common.Address
+}
+
+// RequestBiddingAddress returns the caller's personal L1 bidding address, creating it on first use.
+func (c *Client) RequestBiddingAddress(ctx context.Context) (*BiddingAddress, error) {
+	key, err := GenerateRandomKey()
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := c.contract.SendConfidentialRequest(ctx, "getBiddingAddress", nil, key)
+	if err != nil {
+		return nil, fmt.Errorf("getting bidding address: %w", err)
+	}
+	events, err := c.decoder.Decode(receipt)
+	if err != nil {
+		return nil, err
+	}
+	var res *BiddingAddress
+	for _, event := range events {
+		if e, ok := event.(*EncBiddingAddress); ok && res == nil {
+			res = &BiddingAddress{Receipt: receipt, Events: events, Owner: e.Owner, Encrypted: e.EncryptedL1Address}
+		}
+	}
+	if res == nil {
+		return nil, fmt.Errorf("getting bidding address: no EncBiddingAddress event in receipt")
+	}
+	plaintext, err := aesDecrypt(key, res.Encrypted)
+	if err != nil {
+		return nil, err
+	}
+	res.Address = common.BytesToAddress(plaintext)
+	return res, nil
+}
+
+type EndResult struct {
+	Receipt *types.Receipt
+	Events  []Event
+	// RevealedAddresses are all L1 bidding addresses, in order of registration.
+	RevealedAddresses []common.Address
+	Transfers
+}
+
+// End closes the auction after its end time and reveals all bidding addresses.
+func (c *Client) End(ctx context.Context) (*EndResult, error) {
+	receipt, err := c.contract.SendConfidentialRequest(ctx, "endAuction", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ending auction: %w", err)
+	}
+	events, err := c.decoder.Decode(receipt)
+	if err != nil {
+		return nil, err
+	}
+	res := &EndResult{Receipt: receipt, Events: events, Transfers: collectTransfers(events)}
+	for _, event := range events {
+		if e, ok := event.(*RevealBiddingAddresses); ok {
+			res.RevealedAddresses = e.BidderL1
+		}
+	}
+	return res, nil
+}
+
+type RefuteResult struct {
+	Receipt *types.Receipt
+	Events  []Event
+	// Overridden reports whether the proposed address replaced the registered winner
+	Overridden bool
+	Transfers
+}
+
+// Refute proposes potentialWinnerL1 as the winner of a SealedAuctionProposer. The oracle checks
+// its balance at the end of the auction, the contract only registers it if the balance beats the
+// winning bid and otherwise emits an ErrorEvent.
+func (c *Client) Refute(ctx context.Context, potentialWinnerL1 common.Address) (*RefuteResult, error) {
+	receipt, err := c.contract.SendConfidentialRequest(ctx, "refuteWinner", []interface{}{potentialWinnerL1}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("refuting winner: %w", err)
+	}
+	events, err := c.decoder.Decode(receipt)
+	if err != nil {
+		return nil, err
+	}
+	res := &RefuteResult{Receipt: receipt, Events: events, Transfers: collectTransfers(events)}
+	winner, err := c.callAddress(ctx, "auctionWinnerL1")
+	if err != nil {
+		return nil, err
+	}
+	res.Overridden = winner == potentialWinnerL1 && len(res.Errors) == 0
+	return res, nil
+}
+
+type ClaimResult struct {
+	Receipt *types.Receipt
+	Events  []Event
+	Transfers
+}
+
+// Claim transfers the caller's valuables to returnAddress on L1: the NFT for the winner,
+// the winning bid for the auctioneer and the bid itself for everyone else.
+func (c *Client) Claim(ctx context.Context, returnAddress common.Address) (*ClaimResult, error) {
+	// claim takes the return address as string, see SealedAuction.toAddress
+	return c.transfer(ctx, "claim", returnAddress.Hex())
+}
+
+// BackOutBid returns the caller's bid to returnAddress. Only possible until 15 minutes before the auction ends.
+func (c *Client) BackOutBid(ctx context.Context, returnAddress common.Address) (*ClaimResult, error) {
+	return c.transfer(ctx, "backOutBid", returnAddress)
+}
+
+// RefundNFT returns the NFT to returnAddress before the auction started. Only the auctioneer may call it.
+func (c *Client) RefundNFT(ctx context.Context, returnAddress common.Address) (*ClaimResult, error) {
+	return c.transfer(ctx, "refundNFT", returnAddress)
+}
+
+func (c *Client) transfer(ctx context.Context, method string, returnAddress interface{}) (*ClaimResult, error) {
+	receipt, err := c.contract.SendConfidentialRequest(ctx, method, []interface{}{returnAddress}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	events, err := c.decoder.Decode(receipt)
+	if err != nil {
+		return nil, err
+	}
+	return &ClaimResult{Receipt: receipt, Events: events, Transfers: collectTransfers(events)}, nil
+}
+
+// Outcome is the result of the auction as registered on chain.
+type Outcome struct {
+	WinnerL1    common.Address
+	WinnerSuave common.Address
+	WinningBid  *big.Int
+}
+
+// Outcome reads the registered winner. The addresses are zero as long as the auction has not ended.
+func (c *Client) Outcome(ctx context.Context) (*Outcome, error) {
+	winnerL1, err := c.callAddress(ctx, "auctionWinnerL1")
+	if err != nil {
+		return nil, err
+	}
+	winnerSuave, err := c.callAddress(ctx, "auctionWinnerSuave")
+	if err != nil {
+		return nil, err
+	}
+	winningBid, err := c.callBigInt(ctx, "winningBid")
+	if err != nil {
+		return nil, err
+	}
+	return &Outcome{WinnerL1: winnerL1, WinnerSuave: winnerSuave, WinningBid: winningBid}, nil
+}
+
+// Oracle reads the address of the oracle the auction was deployed with.
+func (c *Client) Oracle(ctx context.Context) (common.Address, error) {
+	return c.callAddress(ctx, "oracle")
+}
+
+// NFTHoldingAddress reads the address generated by SetUp.
+func (c *Client) NFTHoldingAddress(ctx context.Context) (common.Address, error) {
+	return c.callAddress(ctx, "nftHoldingAddress")
+}
+
+func (c *Client) callAddress(ctx context.Context, field string, args ...interface{}) (common.Address, error) {
+	res, err := c.contract.Call(ctx, field, args)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return output[common.Address](field, res)
+}
+
+func (c *Client) callBigInt(ctx context.Context, field string, args ...interface{}) (*big.Int, error) {
+	res, err := c.contract.Call(ctx, field, args)
+	if err != nil {
+		return nil, err
+	}
+	return output[*big.Int](field, res)
+}
+
+// output returns the single output of a call with the expected type
+func output[T any](field string, res []interface{}) (T, error) {
+	var value T
+	if len(res) == 0 {
+		return value, fmt.Errorf("%s: no output", field)
+	}
+	value, ok := res[0].(T)
+	if !ok {
+		return value, fmt.Errorf("%s: unexpected output type %T", field, res[0])
+	}
+	return value, nil
+}
+
+func collectTransfers(events []Event) Transfers {
+	var t Transfers
+	for _, event := range events {
+		switch e := event.(type) {
+		case *TxEvent:
+			t.TxHashes = append(t.TxHashes, e.TxHash)
+		case *EncodedTx:
+			t.SignedTxs = append(t.SignedTxs, e.SignedTx)
+		case *ErrorEvent:
+			t.Errors = append(t.Errors, e.ErrorMsg)
+		}
+	}
+	return t
+}
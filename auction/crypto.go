@@ -0,0 +1,47 @@
+package auction
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	cryptorand "crypto/rand"
+	"fmt"
+)
+
+// GenerateRandomKey returns a fresh AES-256 key used as confidential input for getBiddingAddress.
+func GenerateRandomKey() ([]byte, error) {
+	key := make([]byte, 32)
+	_, err := cryptorand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func aesDecrypt(key []byte, ciphertext []byte) ([]byte, error) {
+	keyBytes := make([]byte, 32)
+	copy(keyBytes[:], key[:])
+
+	c, err := aes.NewCipher(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt ciphertext: %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,208 @@
+package auction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Event is a typed log emitted by an auction or oracle contract. Use a type switch
+// on the concrete event types below to access the fields.
+type Event interface {
+	// EventName is the name of the event in the contract ABI
+	EventName() string
+	// Log returns the raw log the event was decoded from
+	Log() *types.Log
+}
+
+// AuctionOpened is emitted by startAuction.
+type AuctionOpened struct {
+	Raw                  *types.Log
+	ContractAddr         common.Address
+	NFTContractAddress   common.Address
+	NFTTokenID           *big.Int
+	EndTimestamp         *big.Int
+	MinimalBiddingAmount *big.Int
+}
+
+// EncBiddingAddress is emitted by getBiddingAddress. The address is encrypted with the caller's key.
+type EncBiddingAddress struct {
+	Raw                *types.Log
+	Owner              common.Address
+	EncryptedL1Address []byte
+}
+
+// RevealBiddingAddresses is emitted by endAuction.
+type RevealBiddingAddresses struct {
+	Raw      *types.Log
+	BidderL1 []common.Address
+}
+
+// NFTHoldingAddressEvent is emitted by setUpAuction.
+type NFTHoldingAddressEvent struct {
+	Raw               *types.Log
+	NFTHoldingAddress common.Address
+}
+
+// ErrorEvent is emitted by both oracles and by SealedAuctionProposer.
+type ErrorEvent struct {
+	Raw      *types.Log
+	ErrorMsg string
+}
+
+// TxEvent is emitted by the Oracle for every L1 transaction it broadcasts.
+type TxEvent struct {
+	Raw    *types.Log
+	TxHash common.Hash
+}
+
+// EncodedTx is emitted by the OracleProposer for every L1 transaction it signs.
+type EncodedTx struct {
+	Raw *types.Log
+	// SignedTx is the hex encoded RLP of the signed transaction
+	SignedTx string
+}
+
+func (e *AuctionOpened) EventName() string          { return "AuctionOpened" }
+func (e *EncBiddingAddress) EventName() string      { return "EncBiddingAddress" }
+func (e *RevealBiddingAddresses) EventName() string { return "RevealBiddingAddresses" }
+func (e *NFTHoldingAddressEvent) EventName() string { return "NFTHoldingAddressEvent" }
+func (e *ErrorEvent) EventName() string             { return "ErrorEvent" }
+func (e *TxEvent) EventName() string                { return "TxEvent" }
+func (e *EncodedTx) EventName() string              { return "EncodedTx" }
+
+func (e *AuctionOpened) Log() *types.Log          { return e.Raw }
+func (e *EncBiddingAddress) Log() *types.Log      { return e.Raw }
+func (e *RevealBiddingAddresses) Log() *types.Log { return e.Raw }
+func (e *NFTHoldingAddressEvent) Log() *types.Log { return e.Raw }
+func (e *ErrorEvent) Log() *types.Log             { return e.Raw }
+func (e *TxEvent) Log() *types.Log                { return e.Raw }
+func (e *EncodedTx) Log() *types.Log              { return e.Raw }
+
+type eventDecodeFunc func(raw *types.Log, fields map[string]interface{}) (Event, error)
+
+var eventDecoders = map[string]eventDecodeFunc{
+	"AuctionOpened": func(raw *types.Log, fields map[string]interface{}) (Event, error) {
+		e := &AuctionOpened{Raw: raw}
+		return e, firstError(
+			field(fields, "contractAddr", &e.ContractAddr),
+			field(fields, "nftContractAddress", &e.NFTContractAddress),
+			field(fields, "nftTokenId", &e.NFTTokenID),
+			field(fields, "endTimestamp", &e.EndTimestamp),
+			field(fields, "minimalBiddingAmount", &e.MinimalBiddingAmount),
+		)
+	},
+	"EncBiddingAddress": func(raw *types.Log, fields map[string]interface{}) (Event, error) {
+		e := &EncBiddingAddress{Raw: raw}
+		return e, firstError(
+			field(fields, "owner", &e.Owner),
+			field(fields, "encryptedL1Address", &e.EncryptedL1Address),
+		)
+	},
+	"RevealBiddingAddresses": func(raw *types.Log, fields map[string]interface{}) (Event, error) {
+		e := &RevealBiddingAddresses{Raw: raw}
+		return e, field(fields, "bidderL1", &e.BidderL1)
+	},
+	"NFTHoldingAddressEvent": func(raw *types.Log, fields map[string]interface{}) (Event, error) {
+		e := &NFTHoldingAddressEvent{Raw: raw}
+		return e, field(fields, "nftHoldingAddress", &e.NFTHoldingAddress)
+	},
+	"ErrorEvent": func(raw *types.Log, fields map[string]interface{}) (Event, error) {
+		e := &ErrorEvent{Raw: raw}
+		return e, field(fields, "errorMsg", &e.ErrorMsg)
+	},
+	"TxEvent": func(raw *types.Log, fields map[string]interface{}) (Event, error) {
+		var txHash string
+		if err := field(fields, "txHash", &txHash); err != nil {
+			return nil, err
+		}
+		return &TxEvent{Raw: raw, TxHash: common.HexToHash(txHash)}, nil
+	},
+	"EncodedTx": func(raw *types.Log, fields map[string]interface{}) (Event, error) {
+		e := &EncodedTx{Raw: raw}
+		return e, field(fields, "signedTx", &e.SignedTx)
+	},
+}
+
+// Decoder turns receipt logs into typed events. It knows every event of the ABIs it was created
+// with, so one decoder covers SealedAuction/Oracle as well as SealedAuctionProposer/OracleProposer.
+type Decoder struct {
+	events map[common.Hash]abi.Event
+}
+
+// NewDecoder creates a decoder for the events declared in the given ABIs. Events without a
+// typed representation in this package are ignored.
+func NewDecoder(abis ...*abi.ABI) *Decoder {
+	d := &Decoder{events: map[common.Hash]abi.Event{}}
+	for _, contractAbi := range abis {
+		if contractAbi == nil {
+			continue
+		}
+		for name, event := range contractAbi.Events {
+			if _, ok := eventDecoders[name]; ok {
+				d.events[event.ID] = event
+			}
+		}
+	}
+	return d
+}
+
+// Decode returns the typed events of all logs in the receipt in log order. Logs of unknown events are skipped.
+func (d *Decoder) Decode(receipt *types.Receipt) ([]Event, error) {
+	var events []Event
+	for _, log := range receipt.Logs {
+		event, err := d.DecodeLog(log)
+		if err != nil {
+			return nil, err
+		}
+		if event != nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// DecodeLog decodes a single log. It returns nil without an error if the event is unknown.
+func (d *Decoder) DecodeLog(log *types.Log) (Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil
+	}
+	event, ok := d.events[log.Topics[0]]
+	if !ok {
+		return nil, nil
+	}
+	fields, err := event.ParseLog(log)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s log: %w", event.Name, err)
+	}
+	decoded, err := eventDecoders[event.Name](log, fields)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s log: %w", event.Name, err)
+	}
+	return decoded, nil
+}
+
+func field[T any](fields map[string]interface{}, name string, dst *T) error {
+	value, ok := fields[name]
+	if !ok {
+		return fmt.Errorf("missing field %s", name)
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return fmt.Errorf("field %s has type %T, expected %T", name, value, *dst)
+	}
+	*dst = typed
+	return nil
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package auction
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"suave/sealedauction/bindings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// packLog builds the log contractAbi declares for event, all event arguments are unindexed
+func packLog(t *testing.T, contractAbi *abi.ABI, event string, args ...interface{}) *types.Log {
+	t.Helper()
+	e, ok := contractAbi.Events[event]
+	if !ok {
+		t.Fatalf("the ABI does not declare %s", event)
+	}
+	data, err := e.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatalf("packing %s: %v", event, err)
+	}
+	return &types.Log{Topics: []common.Hash{e.ID}, Data: data}
+}
+
+func TestDecodeLog(t *testing.T) {
+	auctionAbi, err := bindings.ParseSealedAuctionProposerABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oracleAbi, err := bindings.ParseOracleABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposerOracleAbi, err := bindings.ParseOracleProposerABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDecoder(auctionAbi, oracleAbi, proposerOracleAbi)
+
+	var (
+		auction = common.Address{0xa1}
+		nft     = common.Address{0xa2}
+		owner   = common.Address{0xa3}
+		holding = common.Address{0xa4}
+		bidders = []common.Address{{0x01}, {0x02}}
+		txHash  = "0x" + common.Bytes2Hex(make([]byte, 32))
+	)
+	for _, tc := range []struct {
+		name string
+		log  *types.Log
+		want Event
+	}{
+		{
+			name: "AuctionOpened",
+			log:  packLog(t, auctionAbi, "AuctionOpened", auction, nft, big.NewInt(1), big.NewInt(1718000000), big.NewInt(1000)),
+			want: &AuctionOpened{ContractAddr: auction, NFTContractAddress: nft, NFTTokenID: big.NewInt(1), EndTimestamp: big.NewInt(1718000000), MinimalBiddingAmount: big.NewInt(1000)},
+		},
+		{
+			name: "EncBiddingAddress",
+			log:  packLog(t, auctionAbi, "EncBiddingAddress", owner, []byte{0xde, 0xad}),
+			want: &EncBiddingAddress{Owner: owner, EncryptedL1Address: []byte{0xde, 0xad}},
+		},
+		{
+			name: "RevealBiddingAddresses",
+			log:  packLog(t, auctionAbi, "RevealBiddingAddresses", bidders),
+			want: &RevealBiddingAddresses{BidderL1: bidders},
+		},
+		{
+			name: "NFTHoldingAddressEvent",
+			log:  packLog(t, auctionAbi, "NFTHoldingAddressEvent", holding),
+			want: &NFTHoldingAddressEvent{NFTHoldingAddress: holding},
+		},
+		{
+			name: "ErrorEvent",
+			log:  packLog(t, auctionAbi, "ErrorEvent", "bid too low"),
+			want: &ErrorEvent{ErrorMsg: "bid too low"},
+		},
+		{
+			name: "TxEvent",
+			log:  packLog(t, oracleAbi, "TxEvent", txHash),
+			want: &TxEvent{TxHash: common.HexToHash(txHash)},
+		},
+		{
+			name: "EncodedTx",
+			log:  packLog(t, proposerOracleAbi, "EncodedTx", "0xf86c"),
+			want: &EncodedTx{SignedTx: "0xf86c"},
+		},
+		{
+			name: "event without a typed representation",
+			log:  packLog(t, oracleAbi, "OffchainLogs", []byte{0x01}),
+		},
+		{
+			name: "log without topics",
+			log:  &types.Log{Data: []byte{0x01}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := d.DecodeLog(tc.log)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.want == nil {
+				if got != nil {
+					t.Fatalf("decoded %#v, expected nothing", got)
+				}
+				return
+			}
+			if got == nil || got.Log() != tc.log {
+				t.Fatalf("decoded %#v without the raw log", got)
+			}
+			// the raw log is checked above, compare the fields
+			reflect.ValueOf(tc.want).Elem().FieldByName("Raw").Set(reflect.ValueOf(tc.log))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("decoded %#v, expected %#v", got, tc.want)
+			}
+			if got.EventName() != tc.name {
+				t.Fatalf("event name %s, expected %s", got.EventName(), tc.name)
+			}
+		})
+	}
+}
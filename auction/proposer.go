@@ -0,0 +1,170 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrCandidatesRejected is returned by Run once the contract rejected every address with the
+// highest bid. The oracle checks the same balances on every attempt, so proposing them again
+// cannot succeed.
+var ErrCandidatesRejected = errors.New("every candidate was rejected")
+
+// Proposer watches a SealedAuctionProposer during its refute period. Once the bidding addresses
+// are revealed it computes the best candidate from their L1 balances at the end of the auction,
+// the same way Verify does, and proposes it via refuteWinner whenever it beats the registered
+// winning bid. Anyone can run a proposer, the account of the client pays for the requests.
+type Proposer struct {
+	client   *Client
+	l1Client *ethclient.Client
+	machine  *StateMachine
+	// rejected are the candidates the contract did not register
+	rejected map[common.Address]bool
+
+	// PollInterval is how often the auction is checked, defaults to 5 seconds
+	PollInterval time.Duration
+	// OnRefute is called after every refuteWinner request, e.g. to record its receipt
+	OnRefute func(res *RefuteResult) error
+	// Logf reports the progress, defaults to discarding it
+	Logf func(format string, args ...interface{})
+}
+
+// NewProposer creates a proposer for the auction of client that reads balances from l1Client.
+func NewProposer(client *Client, l1Client *ethclient.Client) *Proposer {
+	return &Proposer{
+		client:       client,
+		l1Client:     l1Client,
+		machine:      NewStateMachine(client, nil),
+		rejected:     make(map[common.Address]bool),
+		PollInterval: 5 * time.Second,
+		Logf:         func(string, ...interface{}) {},
+	}
+}
+
+// Run blocks until the refute time is over and returns the verification the candidate was chosen
+// from, nil if no bidder took part. A failed refuteWinner request is retried in the next round, a
+// candidate the contract rejected is not proposed again. Run stops with ErrCandidatesRejected once
+// no candidate is left.
+func (p *Proposer) Run(ctx context.Context) (*Verification, error) {
+	if _, ok := p.client.contract.Abi.Methods["refuteTime"]; !ok {
+		return nil, errors.New("refuting the winner needs a SealedAuctionProposer")
+	}
+
+	var v *Verification
+	for {
+		s, err := p.machine.Snapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if s.BlockTime >= *s.RefuteTime {
+			p.Logf("refute time is over at %d", *s.RefuteTime)
+			if v != nil {
+				// compare the final registration with the candidate
+				if v.Registered, err = p.client.Outcome(ctx); err != nil {
+					return nil, err
+				}
+				v.Evaluate()
+			}
+			return v, nil
+		}
+
+		switch {
+		case !s.BiddersRevealed && s.BidderAmount == 0 && s.WinnerL1 != (common.Address{}):
+			p.Logf("nobody bid, the auctioneer keeps the NFT")
+			return nil, nil
+		case !s.BiddersRevealed:
+			p.Logf("waiting for RevealBiddingAddresses, the auction ends at %d", s.EndTime)
+		case v == nil:
+			if v, err = p.client.Verify(ctx, p.l1Client); err != nil {
+				return nil, err
+			}
+			if len(v.Winners) == 0 {
+				p.Logf("none of the %d bidding addresses holds funds at L1 block %d", len(v.Balances), v.Block)
+				return v, nil
+			}
+			p.Logf("best candidate %s bid %s at L1 block %d", v.Winners[0].Hex(), v.Expected.WinningBid, v.Block)
+			continue
+		default:
+			if err := p.propose(ctx, v); err != nil {
+				if ctx.Err() != nil {
+					return v, ctx.Err()
+				}
+				if errors.Is(err, ErrCandidatesRejected) {
+					return v, err
+				}
+				p.Logf("%v, retrying", err)
+			}
+		}
+
+		wait := p.PollInterval
+		if s.BlockTime >= s.EndTime {
+			// one more second so the block of the last check is stamped after the deadline
+			wait = min(wait, time.Duration(*s.RefuteTime-s.BlockTime)*time.Second+time.Second)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return v, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// propose submits the next candidate of v if it beats the registered winning bid. A candidate the
+// contract rejects is remembered and the next address with the same bid is proposed in the next
+// round.
+func (p *Proposer) propose(ctx context.Context, v *Verification) error {
+	outcome, err := p.client.Outcome(ctx)
+	if err != nil {
+		return err
+	}
+	candidate, err := nextCandidate(v, *outcome, p.rejected)
+	if err != nil || candidate == (common.Address{}) {
+		return err
+	}
+	bid := v.Expected.WinningBid
+	p.Logf("proposing %s with %s over %s with %s", candidate.Hex(), bid, outcome.WinnerL1.Hex(), outcome.WinningBid)
+	res, err := p.client.Refute(ctx, candidate)
+	if err != nil {
+		return err
+	}
+	if p.OnRefute != nil {
+		if err := p.OnRefute(res); err != nil {
+			return err
+		}
+	}
+	if !res.Overridden {
+		p.rejected[candidate] = true
+		return fmt.Errorf("proposing %s was rejected: %v", candidate.Hex(), res.Errors)
+	}
+	p.Logf("registered %s with %s", candidate.Hex(), bid)
+	return nil
+}
+
+// nextCandidate returns the first address of v.Winners that was not rejected, the zero address if
+// registered already holds the highest bid. Every address of v.Winners bid the same amount and a
+// tie never replaces the registered winner, the contract keeps the first proposed address. So the
+// other winners only matter if the contract rejects the first one.
+func nextCandidate(v *Verification, registered Outcome, rejected map[common.Address]bool) (common.Address, error) {
+	bid := v.Expected.WinningBid
+	if len(v.Winners) == 0 || bid.Cmp(registered.WinningBid) <= 0 {
+		return common.Address{}, nil
+	}
+	for _, winner := range v.Winners {
+		if winner == registered.WinnerL1 {
+			return common.Address{}, nil
+		}
+	}
+	for _, winner := range v.Winners {
+		if !rejected[winner] {
+			return winner, nil
+		}
+	}
+	return common.Address{}, fmt.Errorf("%w: %d addresses with %s", ErrCandidatesRejected, len(v.Winners), bid)
+}
@@ -0,0 +1,81 @@
+package auction
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNextCandidate(t *testing.T) {
+	var (
+		auctioneer = common.Address{0xa0}
+		alice      = common.Address{0x01}
+		bob        = common.Address{0x02}
+		zero       = common.Address{}
+	)
+	tie := &Verification{Expected: Outcome{WinnerL1: alice, WinningBid: big.NewInt(20)}, Winners: []common.Address{alice, bob}}
+	for _, tc := range []struct {
+		name       string
+		v          *Verification
+		registered Outcome
+		rejected   []common.Address
+
+		candidate common.Address
+		err       error
+	}{
+		{
+			name:       "first winner beats the registered bid",
+			v:          tie,
+			registered: Outcome{WinnerL1: auctioneer, WinningBid: big.NewInt(10)},
+			candidate:  alice,
+		},
+		{
+			name:       "registered bid is as high",
+			v:          tie,
+			registered: Outcome{WinnerL1: auctioneer, WinningBid: big.NewInt(20)},
+			candidate:  zero,
+		},
+		{
+			name:       "a tied winner is registered",
+			v:          tie,
+			registered: Outcome{WinnerL1: bob, WinningBid: big.NewInt(10)},
+			candidate:  zero,
+		},
+		{
+			name:       "next tied winner after a rejection",
+			v:          tie,
+			registered: Outcome{WinnerL1: auctioneer, WinningBid: big.NewInt(10)},
+			rejected:   []common.Address{alice},
+			candidate:  bob,
+		},
+		{
+			name:       "every winner rejected",
+			v:          tie,
+			registered: Outcome{WinnerL1: auctioneer, WinningBid: big.NewInt(10)},
+			rejected:   []common.Address{alice, bob},
+			err:        ErrCandidatesRejected,
+		},
+		{
+			name:       "no winners",
+			v:          &Verification{Expected: Outcome{WinningBid: new(big.Int)}},
+			registered: Outcome{WinnerL1: auctioneer, WinningBid: new(big.Int)},
+			candidate:  zero,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rejected := make(map[common.Address]bool)
+			for _, address := range tc.rejected {
+				rejected[address] = true
+			}
+			candidate, err := nextCandidate(tc.v, tc.registered, rejected)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error %v, expected %v", err, tc.err)
+			}
+			if candidate != tc.candidate {
+				t.Fatalf("candidate %x, expected %x", candidate, tc.candidate)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package auction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"suave/sealedauction/framework"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// State is the lifecycle state of an auction. The contracts do not store it explicitly, it is
+// derived from the public fields and the block timestamp the same way the modifiers do.
+type State int
+
+const (
+	// StateDeployed: the NFT holding address has not been generated yet
+	StateDeployed State = iota
+	// StateSetUp: the NFT holding address exists but does not own the NFT yet
+	StateSetUp
+	// StateNFTDeposited: the NFT holding address owns the NFT, startAuction has not been called
+	StateNFTDeposited
+	// StateBidding: the auction started and auctionEndTime is not reached yet
+	StateBidding
+	// StateEnded: auctionEndTime is reached but endAuction has not registered a result yet
+	StateEnded
+	// StateRefuting: SealedAuctionProposer only, the bidders are revealed and anyone may propose a winner.
+	// The contract accepts refuteWinner until a winner is registered, even after refuteTime.
+	StateRefuting
+	// StateClaiming: the winner is registered and everyone can claim their valuables
+	StateClaiming
+)
+
+var stateNames = [...]string{"deployed", "set up", "NFT deposited", "bidding", "ended", "refuting", "claiming"}
+
+func (s State) String() string {
+	if s < 0 || int(s) >= len(stateNames) {
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+	return stateNames[s]
+}
+
+// Transition is a state changing request, named after the contract method.
+type Transition string
+
+const (
+	TransitionSetUp     Transition = "setUpAuction"
+	TransitionStart     Transition = "startAuction"
+	TransitionBid       Transition = "getBiddingAddress"
+	TransitionBackOut   Transition = "backOutBid"
+	TransitionEnd       Transition = "endAuction"
+	TransitionRefute    Transition = "refuteWinner"
+	TransitionClaim     Transition = "claim"
+	TransitionRefundNFT Transition = "refundNFT"
+)
+
+// ErrTransitionNotAllowed is returned when a transition is not possible in the current state and
+// will not become possible by waiting.
+var ErrTransitionNotAllowed = errors.New("transition not allowed")
+
+// backOutWindow is how long before auctionEndTime bidders can no longer back out (inBackOutTime)
+const backOutWindow = 15 * 60
+
+// Snapshot holds the on-chain fields the state is derived from.
+type Snapshot struct {
+	NFTHoldingAddress common.Address
+	NFTContract       common.Address
+	TokenID           *big.Int
+	// NFTDeposited is only known if the state machine has an NFTOwnerFunc
+	NFTDeposited bool
+	HasStarted   bool
+	EndTime      uint64
+	// RefuteTime is nil for the SealedAuction
+	RefuteTime      *uint64
+	WinnerL1        common.Address
+	BidderAmount    uint64
+	BiddersRevealed bool
+	// BlockTime is the timestamp the next request executes at
+	BlockTime uint64
+}
+
+// Proposer reports whether the snapshot was taken from a SealedAuctionProposer.
+func (s *Snapshot) Proposer() bool {
+	return s.RefuteTime != nil
+}
+
+// State derives the lifecycle state.
+func (s *Snapshot) State() State {
+	switch {
+	case !s.HasStarted && s.NFTHoldingAddress == (common.Address{}):
+		return StateDeployed
+	case !s.HasStarted && !s.NFTDeposited:
+		return StateSetUp
+	case !s.HasStarted:
+		return StateNFTDeposited
+	case s.BlockTime < s.EndTime:
+		return StateBidding
+	case !s.Proposer() && s.WinnerL1 == (common.Address{}):
+		return StateEnded
+	case !s.Proposer():
+		return StateClaiming
+	case !s.BiddersRevealed && s.WinnerL1 == (common.Address{}):
+		return StateEnded
+	case s.BlockTime < *s.RefuteTime || s.WinnerL1 == (common.Address{}):
+		return StateRefuting
+	default:
+		return StateClaiming
+	}
+}
+
+// Check reports whether t may be requested now. A positive duration means t becomes possible
+// after waiting that long; an error wrapping ErrTransitionNotAllowed means it never will
+// without another transition happening first.
+func (s *Snapshot) Check(t Transition) (time.Duration, error) {
+	state := s.State()
+	notAllowed := func(reason string) (time.Duration, error) {
+		return 0, fmt.Errorf("%w: %s in state %s: %s", ErrTransitionNotAllowed, t, state, reason)
+	}
+	switch t {
+	case TransitionSetUp:
+		if s.HasStarted {
+			return notAllowed("auction has already started")
+		}
+	case TransitionStart:
+		if state == StateDeployed {
+			return notAllowed("auction is not set up")
+		}
+		if s.HasStarted {
+			return notAllowed("auction has already started")
+		}
+		if s.BlockTime >= s.EndTime {
+			return notAllowed("auction end time is over already")
+		}
+	case TransitionBid:
+		if state != StateBidding {
+			return notAllowed("auction is not open for bids")
+		}
+	case TransitionBackOut:
+		if state != StateBidding || s.BlockTime+backOutWindow > s.EndTime {
+			return notAllowed("backing out is only possible until 15 minutes before the auction ends")
+		}
+	case TransitionEnd:
+		if !s.HasStarted {
+			return notAllowed("auction not yet started")
+		}
+		return s.until(s.EndTime), nil
+	case TransitionRefute:
+		if !s.Proposer() {
+			return notAllowed("only the proposer version can refute the winner")
+		}
+		if state != StateRefuting {
+			return notAllowed("bidders are not revealed")
+		}
+	case TransitionClaim:
+		switch {
+		case state == StateClaiming:
+		case state == StateRefuting && s.WinnerL1 != (common.Address{}):
+			return s.until(*s.RefuteTime), nil
+		default:
+			return notAllowed("no winner registered")
+		}
+	case TransitionRefundNFT:
+		if state == StateDeployed || s.HasStarted {
+			return notAllowed("NFT can only be refunded before the auction started")
+		}
+	default:
+		return 0, fmt.Errorf("unknown transition %q", t)
+	}
+	return 0, nil
+}
+
+func (s *Snapshot) until(timestamp uint64) time.Duration {
+	if s.BlockTime >= timestamp {
+		return 0
+	}
+	return time.Duration(timestamp-s.BlockTime) * time.Second
+}
+
+// NFTOwnerFunc looks up the current owner of an NFT on L1.
+type NFTOwnerFunc func(ctx context.Context, nftContract common.Address, tokenID *big.Int) (common.Address, error)
+
+// StateMachine derives the state of a deployed auction and waits for transitions to become possible,
+// so tooling can pick up an auction in any phase.
+type StateMachine struct {
+	client   *Client
+	nftOwner NFTOwnerFunc
+}
+
+// NewStateMachine creates a state machine for the auction. nftOwner may be nil, then a set up
+// auction is reported as StateSetUp until it started.
+func NewStateMachine(client *Client, nftOwner NFTOwnerFunc) *StateMachine {
+	return &StateMachine{client: client, nftOwner: nftOwner}
+}
+
+// Snapshot reads the current on-chain state of the auction.
+func (m *StateMachine) Snapshot(ctx context.Context) (*Snapshot, error) {
+	c := m.client
+	s := &Snapshot{}
+	var err error
+	if s.NFTHoldingAddress, err = c.NFTHoldingAddress(ctx); err != nil {
+		return nil, err
+	}
+	if s.NFTContract, err = c.callAddress(ctx, "nftContract"); err != nil {
+		return nil, err
+	}
+	if s.TokenID, err = c.callBigInt(ctx, "tokenId"); err != nil {
+		return nil, err
+	}
+	started, err := c.contract.Call(ctx, "auctionHasStarted", nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.HasStarted, err = output[bool]("auctionHasStarted", started); err != nil {
+		return nil, err
+	}
+	endTime, err := c.callBigInt(ctx, "auctionEndTime")
+	if err != nil {
+		return nil, err
+	}
+	s.EndTime = endTime.Uint64()
+	if _, ok := c.contract.Abi.Methods["refuteTime"]; ok {
+		refuteTime, err := c.callBigInt(ctx, "refuteTime")
+		if err != nil {
+			return nil, err
+		}
+		t := refuteTime.Uint64()
+		s.RefuteTime = &t
+	}
+	if s.WinnerL1, err = c.callAddress(ctx, "auctionWinnerL1"); err != nil {
+		return nil, err
+	}
+	bidderAmount, err := c.callBigInt(ctx, "bidderAmount")
+	if err != nil {
+		return nil, err
+	}
+	s.BidderAmount = bidderAmount.Uint64()
+	if s.BidderAmount > 0 {
+		// the array getter reverts as long as endAuction has not stored the revealed addresses
+		_, err := c.callAddress(ctx, "revealedL1Addresses", big.NewInt(0))
+		var revertErr *framework.RevertError
+		switch {
+		case err == nil:
+			s.BiddersRevealed = true
+		case !errors.As(err, &revertErr):
+			return nil, err
+		}
+	}
+	if !s.HasStarted && s.NFTHoldingAddress != (common.Address{}) && m.nftOwner != nil {
+		owner, err := m.nftOwner(ctx, s.NFTContract, s.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("looking up NFT owner: %w", err)
+		}
+		s.NFTDeposited = owner == s.NFTHoldingAddress
+	}
+	header, err := c.contract.RPC().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// on chains that only produce blocks on demand the next block is stamped with the wall clock
+	s.BlockTime = max(header.Time, uint64(time.Now().Unix()))
+	return s, nil
+}
+
+// State reads the current state of the auction.
+func (m *StateMachine) State(ctx context.Context) (State, error) {
+	s, err := m.Snapshot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return s.State(), nil
+}
+
+// Await blocks until t is possible and returns the snapshot it was checked against. It returns
+// immediately with an error wrapping ErrTransitionNotAllowed if t can not become possible by waiting.
+func (m *StateMachine) Await(ctx context.Context, t Transition) (*Snapshot, error) {
+	for {
+		s, err := m.Snapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		wait, err := s.Check(t)
+		if err != nil {
+			return nil, err
+		}
+		if wait == 0 {
+			return s, nil
+		}
+		// one more second so the block of the request is stamped after the deadline
+		timer := time.NewTimer(wait + time.Second)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
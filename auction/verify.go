@@ -0,0 +1,178 @@
+package auction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"suave/sealedauction/l1"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// BidderBalance is the balance of a revealed bidding address at the end of the auction.
+type BidderBalance struct {
+	Address common.Address `json:"address"`
+	Balance *big.Int       `json:"balance"`
+}
+
+// Verification compares the outcome registered on SUAVE with the outcome recomputed from the L1
+// balances of the revealed bidding addresses.
+type Verification struct {
+	EndTime uint64 `json:"endTime"`
+	// Block is the last L1 block at or before EndTime, the block the oracle looks up on Etherscan
+	Block      uint64          `json:"block"`
+	Balances   []BidderBalance `json:"balances"`
+	MinimalBid *big.Int        `json:"minimalBid"`
+	Auctioneer common.Address  `json:"auctioneer"`
+	Proposer   bool            `json:"proposer"`
+
+	Registered *Outcome `json:"registered"`
+	// Expected is the outcome derived from the balances. Winners lists every address with the
+	// highest bid, the SealedAuction picks the first and the proposer variant the first proposed.
+	Expected Outcome          `json:"expected"`
+	Winners  []common.Address `json:"winners,omitempty"`
+	// BelowMinimalBid is set if the highest bid is below minimalBid and the auctioneer wins
+	BelowMinimalBid bool `json:"belowMinimalBid"`
+
+	WinnerMatches bool `json:"winnerMatches"`
+	BidMatches    bool `json:"bidMatches"`
+}
+
+// OK reports whether the registered winner and winning bid match the balances.
+func (v *Verification) OK() bool {
+	return v.WinnerMatches && v.BidMatches
+}
+
+// Verify recomputes the winner of an ended auction independently of the oracle. It reads the
+// revealed bidding addresses and the end time from SUAVE, searches the L1 block at the end time
+// and compares the balances at that block with the registered winner and winning bid.
+func (c *Client) Verify(ctx context.Context, l1Client *ethclient.Client) (*Verification, error) {
+	v := &Verification{}
+	_, v.Proposer = c.contract.Abi.Methods["refuteTime"]
+	endTime, err := c.callBigInt(ctx, "auctionEndTime")
+	if err != nil {
+		return nil, err
+	}
+	v.EndTime = endTime.Uint64()
+	if v.MinimalBid, err = c.callBigInt(ctx, "minimalBid"); err != nil {
+		return nil, err
+	}
+	if v.Auctioneer, err = c.callAddress(ctx, "auctioneerSUAVE"); err != nil {
+		return nil, err
+	}
+	if v.Registered, err = c.Outcome(ctx); err != nil {
+		return nil, err
+	}
+	bidderAmount, err := c.callBigInt(ctx, "bidderAmount")
+	if err != nil {
+		return nil, err
+	}
+	var revealed []common.Address
+	if bidderAmount.Sign() > 0 {
+		if revealed, err = c.revealedAddresses(ctx); err != nil {
+			return nil, err
+		}
+	} else if v.Registered.WinnerL1 == (common.Address{}) {
+		return nil, fmt.Errorf("auction %s has not ended yet", c.Address().Hex())
+	}
+
+	block, ok, err := l1.BlockAtTime(ctx, l1Client, v.EndTime, true)
+	if err != nil {
+		return nil, fmt.Errorf("searching the L1 block at %d: %w", v.EndTime, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("no L1 block at or before %d", v.EndTime)
+	}
+	v.Block = block
+
+	for _, address := range revealed {
+		balance, err := l1Client.BalanceAt(ctx, address, new(big.Int).SetUint64(block))
+		if err != nil {
+			return nil, fmt.Errorf("balance of %s at block %d: %w", address.Hex(), block, err)
+		}
+		v.Balances = append(v.Balances, BidderBalance{Address: address, Balance: balance})
+	}
+	v.Evaluate()
+	return v, nil
+}
+
+// Evaluate derives the expected outcome from Balances, MinimalBid, Auctioneer and Proposer and
+// compares it with Registered.
+func (v *Verification) Evaluate() {
+	// the oracle only replaces the winner on a strictly higher balance
+	highest := new(big.Int)
+	v.Winners = nil
+	for _, b := range v.Balances {
+		switch b.Balance.Cmp(highest) {
+		case 1:
+			highest = b.Balance
+			v.Winners = []common.Address{b.Address}
+		case 0:
+			if b.Balance.Sign() > 0 {
+				v.Winners = append(v.Winners, b.Address)
+			}
+		}
+	}
+
+	v.BelowMinimalBid = false
+	switch {
+	case len(v.Balances) == 0:
+		// nobody bid, the auctioneer keeps the NFT
+		v.Expected = Outcome{WinnerL1: v.Auctioneer, WinnerSuave: v.Auctioneer, WinningBid: new(big.Int)}
+	case len(v.Winners) == 0:
+		// no bidding address holds funds, the oracle reports the zero address as winner and the
+		// proposer variant registers none
+		v.Expected = Outcome{WinningBid: new(big.Int)}
+		if !v.Proposer && v.MinimalBid.Sign() > 0 {
+			v.BelowMinimalBid = true
+			v.Expected.WinnerL1 = v.Auctioneer
+		}
+	case !v.Proposer && highest.Cmp(v.MinimalBid) < 0:
+		v.BelowMinimalBid = true
+		v.Expected = Outcome{WinnerL1: v.Auctioneer, WinnerSuave: v.Auctioneer, WinningBid: new(big.Int)}
+	default:
+		v.Expected = Outcome{WinnerL1: v.Winners[0], WinningBid: highest}
+	}
+
+	v.BidMatches = v.Registered.WinningBid.Cmp(v.Expected.WinningBid) == 0
+	v.WinnerMatches = v.Registered.WinnerL1 == v.Expected.WinnerL1
+	if v.Proposer && len(v.Winners) > 1 {
+		// ties go to the first proposed address
+		for _, winner := range v.Winners {
+			v.WinnerMatches = v.WinnerMatches || v.Registered.WinnerL1 == winner
+		}
+	}
+}
+
+// revealedAddresses reads the bidding addresses of the last RevealBiddingAddresses event
+func (c *Client) revealedAddresses(ctx context.Context) ([]common.Address, error) {
+	event, ok := c.contract.Abi.Events["RevealBiddingAddresses"]
+	if !ok {
+		return nil, fmt.Errorf("the auction ABI has no RevealBiddingAddresses event")
+	}
+	logs, err := c.contract.RPC().FilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{c.Address()},
+		Topics:    [][]common.Hash{{event.ID}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("filtering RevealBiddingAddresses logs: %w", err)
+	}
+	var revealed []common.Address
+	found := false
+	for i := range logs {
+		decoded, err := c.decoder.DecodeLog(&logs[i])
+		if err != nil {
+			return nil, err
+		}
+		if e, ok := decoded.(*RevealBiddingAddresses); ok {
+			revealed, found = e.BidderL1, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("auction %s has not revealed the bidding addresses yet", c.Address().Hex())
+	}
+	return revealed, nil
+}
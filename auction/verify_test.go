@@ -0,0 +1,172 @@
+package auction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEvaluate(t *testing.T) {
+	var (
+		auctioneer = common.Address{0xa0}
+		alice      = common.Address{0x01}
+		bob        = common.Address{0x02}
+		carol      = common.Address{0x03}
+		zero       = common.Address{}
+	)
+	balances := func(pairs ...interface{}) []BidderBalance {
+		var b []BidderBalance
+		for i := 0; i < len(pairs); i += 2 {
+			b = append(b, BidderBalance{Address: pairs[i].(common.Address), Balance: big.NewInt(int64(pairs[i+1].(int)))})
+		}
+		return b
+	}
+	for _, tc := range []struct {
+		name       string
+		proposer   bool
+		minimalBid int64
+		balances   []BidderBalance
+		registered Outcome
+
+		winner          common.Address
+		bid             int64
+		winners         int
+		belowMinimalBid bool
+		ok              bool
+	}{
+		{
+			name:       "no bidders",
+			minimalBid: 10,
+			registered: Outcome{WinnerL1: auctioneer, WinningBid: big.NewInt(0)},
+			winner:     auctioneer,
+			ok:         true,
+		},
+		{
+			name:            "no funds with a minimal bid",
+			minimalBid:      10,
+			balances:        balances(alice, 0, bob, 0),
+			registered:      Outcome{WinnerL1: auctioneer, WinningBid: big.NewInt(0)},
+			winner:          auctioneer,
+			belowMinimalBid: true,
+			ok:              true,
+		},
+		{
+			name:       "no funds without a minimal bid",
+			balances:   balances(alice, 0, bob, 0),
+			registered: Outcome{WinnerL1: zero, WinningBid: big.NewInt(0)},
+			winner:     zero,
+			ok:         true,
+		},
+		{
+			name:       "no funds in the proposer variant",
+			proposer:   true,
+			minimalBid: 10,
+			balances:   balances(alice, 0),
+			registered: Outcome{WinnerL1: zero, WinningBid: big.NewInt(0)},
+			winner:     zero,
+			ok:         true,
+		},
+		{
+			name:       "highest bid wins",
+			minimalBid: 10,
+			balances:   balances(alice, 20, bob, 30, carol, 25),
+			registered: Outcome{WinnerL1: bob, WinningBid: big.NewInt(30)},
+			winner:     bob,
+			bid:        30,
+			winners:    1,
+			ok:         true,
+		},
+		{
+			name:       "tie goes to the first bidder",
+			balances:   balances(alice, 30, bob, 30),
+			registered: Outcome{WinnerL1: alice, WinningBid: big.NewInt(30)},
+			winner:     alice,
+			bid:        30,
+			winners:    2,
+			ok:         true,
+		},
+		{
+			name:       "tie registered for the second bidder",
+			balances:   balances(alice, 30, bob, 30),
+			registered: Outcome{WinnerL1: bob, WinningBid: big.NewInt(30)},
+			winner:     alice,
+			bid:        30,
+			winners:    2,
+		},
+		{
+			name:       "tie goes to the first proposed",
+			proposer:   true,
+			balances:   balances(alice, 30, bob, 30, carol, 10),
+			registered: Outcome{WinnerL1: bob, WinningBid: big.NewInt(30)},
+			winner:     alice,
+			bid:        30,
+			winners:    2,
+			ok:         true,
+		},
+		{
+			name:            "below the minimal bid",
+			minimalBid:      50,
+			balances:        balances(alice, 20, bob, 30),
+			registered:      Outcome{WinnerL1: auctioneer, WinningBid: big.NewInt(0)},
+			winner:          auctioneer,
+			winners:         1,
+			belowMinimalBid: true,
+			ok:              true,
+		},
+		{
+			name:       "proposer ignores the minimal bid",
+			proposer:   true,
+			minimalBid: 50,
+			balances:   balances(alice, 20, bob, 30),
+			registered: Outcome{WinnerL1: bob, WinningBid: big.NewInt(30)},
+			winner:     bob,
+			bid:        30,
+			winners:    1,
+			ok:         true,
+		},
+		{
+			name:       "wrong winner",
+			balances:   balances(alice, 20, bob, 30),
+			registered: Outcome{WinnerL1: alice, WinningBid: big.NewInt(30)},
+			winner:     bob,
+			bid:        30,
+			winners:    1,
+		},
+		{
+			name:       "wrong bid",
+			balances:   balances(alice, 20, bob, 30),
+			registered: Outcome{WinnerL1: bob, WinningBid: big.NewInt(20)},
+			winner:     bob,
+			bid:        30,
+			winners:    1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			registered := tc.registered
+			v := &Verification{
+				Balances:   tc.balances,
+				MinimalBid: big.NewInt(tc.minimalBid),
+				Auctioneer: auctioneer,
+				Proposer:   tc.proposer,
+				Registered: &registered,
+			}
+			v.Evaluate()
+			if v.Expected.WinnerL1 != tc.winner {
+				t.Errorf("expected winner %s, want %s", v.Expected.WinnerL1.Hex(), tc.winner.Hex())
+			}
+			if v.Expected.WinningBid.Cmp(big.NewInt(tc.bid)) != 0 {
+				t.Errorf("expected bid %s, want %d", v.Expected.WinningBid, tc.bid)
+			}
+			if len(v.Winners) != tc.winners {
+				t.Errorf("%d winners, want %d", len(v.Winners), tc.winners)
+			}
+			if v.BelowMinimalBid != tc.belowMinimalBid {
+				t.Errorf("BelowMinimalBid %t, want %t", v.BelowMinimalBid, tc.belowMinimalBid)
+			}
+			if v.OK() != tc.ok {
+				t.Errorf("OK %t (winner %t, bid %t), want %t", v.OK(), v.WinnerMatches, v.BidMatches, tc.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package bindings contains typed Go bindings for the auction and oracle contracts. The files are
+// generated from the forge artifacts, so run `forge build` and `go generate ./bindings` after
+// changing the ABI of a contract.
+package bindings
+
+//go:generate go run ../cmd/bindgen -pkg bindings -artifacts ../out -out . SealedAuction.sol/SealedAuction.json SealedAuctionProposer.sol/SealedAuctionProposer.json Oracle.sol/Oracle.json OracleProposer.sol/OracleProposer.json
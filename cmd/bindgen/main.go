@@ -0,0 +1,355 @@
+// Command bindgen generates typed Go bindings for the auction contracts from the Foundry
+// artifacts in out/. The bindings wrap a framework.Contract, so view methods are executed
+// with Contract.Call and every other method is sent as a confidential request.
+//
+//	go run ./cmd/bindgen -pkg bindings -artifacts out -out bindings SealedAuction.sol/SealedAuction.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+func main() {
+	pkg := flag.String("pkg", "bindings", "package name of the generated files")
+	artifactDir := flag.String("artifacts", "out", "directory of the forge build artifacts")
+	outDir := flag.String("out", ".", "directory the generated files are written to")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		log.Fatal("usage: bindgen [flags] <Contract.sol/Contract.json>...")
+	}
+	for _, path := range flag.Args() {
+		if err := generate(*pkg, *artifactDir, path, *outDir); err != nil {
+			log.Fatalf("generating bindings for %s: %v", path, err)
+		}
+	}
+}
+
+// abiEntry is one element of the ABI array in a forge artifact
+type abiEntry struct {
+	Type            string     `json:"type"`
+	Name            string     `json:"name"`
+	Inputs          []abiParam `json:"inputs"`
+	Outputs         []abiParam `json:"outputs"`
+	StateMutability string     `json:"stateMutability"`
+}
+
+type abiParam struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	InternalType string `json:"internalType"`
+}
+
+type param struct {
+	Name   string
+	GoType string
+}
+
+type method struct {
+	GoName    string
+	Name      string
+	Signature string
+	Inputs    []param
+	Outputs   []param
+	View      bool
+}
+
+type contract struct {
+	Package  string
+	Source   string
+	Name     string
+	ABI      string
+	Methods  []method
+	Multiple []method
+}
+
+func generate(pkg, artifactDir, path, outDir string) error {
+	data, err := os.ReadFile(filepath.Join(artifactDir, path))
+	if err != nil {
+		return err
+	}
+	var artifact struct {
+		Abi json.RawMessage `json:"abi"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return err
+	}
+	var entries []abiEntry
+	if err := json.Unmarshal(artifact.Abi, &entries); err != nil {
+		return err
+	}
+	compact := new(bytes.Buffer)
+	if err := json.Compact(compact, artifact.Abi); err != nil {
+		return err
+	}
+
+	c := contract{
+		Package: pkg,
+		Source:  filepath.ToSlash(path),
+		Name:    strings.TrimSuffix(filepath.Base(path), ".json"),
+		ABI:     compact.String(),
+	}
+	seen := map[string]bool{"Contract": true, "Address": true, "Ref": true}
+	for _, entry := range entries {
+		if entry.Type != "function" {
+			continue
+		}
+		m, err := newMethod(entry)
+		if err != nil {
+			return fmt.Errorf("method %s: %w", entry.Name, err)
+		}
+		if seen[m.GoName] {
+			return fmt.Errorf("method %s: Go name %s is already taken", entry.Name, m.GoName)
+		}
+		seen[m.GoName] = true
+		c.Methods = append(c.Methods, m)
+		if m.View && len(m.Outputs) > 1 {
+			c.Multiple = append(c.Multiple, m)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := bindingTemplate.Execute(&buf, c); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting generated code: %w", err)
+	}
+	return os.WriteFile(filepath.Join(outDir, strings.ToLower(c.Name)+".go"), src, 0644)
+}
+
+func newMethod(entry abiEntry) (method, error) {
+	m := method{
+		GoName: goName(entry.Name),
+		Name:   entry.Name,
+		View:   entry.StateMutability == "view" || entry.StateMutability == "pure",
+	}
+	var inputs, outputs []string
+	for i, in := range entry.Inputs {
+		goType, err := goType(in.Type)
+		if err != nil {
+			return m, err
+		}
+		m.Inputs = append(m.Inputs, param{Name: argName(in.Name, i), GoType: goType})
+		inputs = append(inputs, solidityParam(in))
+	}
+	for i, out := range entry.Outputs {
+		goType, err := goType(out.Type)
+		if err != nil {
+			return m, err
+		}
+		name := goName(out.Name)
+		if name == "" {
+			name = fmt.Sprintf("Arg%d", i)
+		}
+		m.Outputs = append(m.Outputs, param{Name: name, GoType: goType})
+		outputs = append(outputs, solidityParam(out))
+	}
+	m.Signature = fmt.Sprintf("function %s(%s)", entry.Name, strings.Join(inputs, ", "))
+	if entry.StateMutability != "" && entry.StateMutability != "nonpayable" {
+		m.Signature += " " + entry.StateMutability
+	}
+	if len(outputs) > 0 {
+		m.Signature += fmt.Sprintf(" returns(%s)", strings.Join(outputs, ", "))
+	}
+	return m, nil
+}
+
+func solidityParam(p abiParam) string {
+	if p.Name == "" {
+		return p.Type
+	}
+	return p.Type + " " + p.Name
+}
+
+// goType maps an ABI type to the Go type go-ethereum packs and unpacks it as
+func goType(t string) (string, error) {
+	if i := strings.LastIndex(t, "["); i >= 0 && strings.HasSuffix(t, "]") {
+		elem, err := goType(t[:i])
+		if err != nil {
+			return "", err
+		}
+		return t[i:len(t)-1] + "]" + elem, nil
+	}
+	switch {
+	case t == "address":
+		return "common.Address", nil
+	case t == "bool":
+		return "bool", nil
+	case t == "string":
+		return "string", nil
+	case t == "bytes":
+		return "[]byte", nil
+	case strings.HasPrefix(t, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(t, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return "", fmt.Errorf("invalid type %s", t)
+		}
+		return fmt.Sprintf("[%d]byte", size), nil
+	case strings.HasPrefix(t, "uint"), strings.HasPrefix(t, "int"):
+		prefix := strings.TrimRight(t, "0123456789")
+		size := 256
+		if bits := strings.TrimPrefix(t, prefix); bits != "" {
+			var err error
+			if size, err = strconv.Atoi(bits); err != nil {
+				return "", fmt.Errorf("invalid type %s", t)
+			}
+		}
+		switch size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, size), nil
+		}
+		return "*big.Int", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// goName turns a solidity identifier into an exported Go identifier, e.g. PRIVATE_KEYS -> PRIVATEKEYS
+func goName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// argName turns a solidity parameter name into an unexported Go identifier
+func argName(name string, i int) string {
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+	if token.IsKeyword(name) || name == "ctx" || name == "confidentialInput" {
+		name += "_"
+	}
+	return name
+}
+
+var bindingTemplate = template.Must(template.New("binding").Parse(`// Code generated by bindgen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"suave/sealedauction/framework"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = common.Address{}
+	_ = types.Receipt{}
+)
+
+// {{.Name}}ABI is the ABI of the {{.Name}} contract the bindings were generated from.
+const {{.Name}}ABI = ` + "`{{.ABI}}`" + `
+
+// Parse{{.Name}}ABI parses {{.Name}}ABI.
+func Parse{{.Name}}ABI() (*abi.ABI, error) {
+	parsed, err := abi.JSON(strings.NewReader({{.Name}}ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// {{.Name}} is a typed binding for a deployed {{.Name}} contract.
+type {{.Name}} struct {
+	contract *framework.Contract
+}
+
+// New{{.Name}} binds a deployed {{.Name}} contract.
+func New{{.Name}}(contract *framework.Contract) *{{.Name}} {
+	return &{{.Name}}{contract: contract}
+}
+
+// Contract returns the underlying framework contract.
+func (c *{{.Name}}) Contract() *framework.Contract {
+	return c.contract
+}
+
+// Address returns the address of the contract on SUAVE.
+func (c *{{.Name}}) Address() common.Address {
+	return c.contract.Raw().Address()
+}
+
+// Ref returns a binding that sends its requests from acct.
+func (c *{{.Name}}) Ref(acct *framework.PrivKey) *{{.Name}} {
+	return &{{.Name}}{contract: c.contract.Ref(acct)}
+}
+{{range .Multiple}}
+// {{$.Name}}{{.GoName}}Output holds the return values of {{.Name}}.
+type {{$.Name}}{{.GoName}}Output struct {
+{{- range .Outputs}}
+	{{.Name}} {{.GoType}}
+{{- end}}
+}
+{{end}}
+{{- range .Methods}}
+{{- if .View}}
+// {{.GoName}} calls the view method {{.Name}}.
+//
+// Solidity: {{.Signature}}
+func (c *{{$.Name}}) {{.GoName}}(ctx context.Context{{range .Inputs}}, {{.Name}} {{.GoType}}{{end}}) ({{if eq (len .Outputs) 1}}{{(index .Outputs 0).GoType}}{{else if .Outputs}}*{{$.Name}}{{.GoName}}Output{{else}}struct{}{{end}}, error) {
+{{- if eq (len .Outputs) 1}}
+	var ret {{(index .Outputs 0).GoType}}
+{{- else if .Outputs}}
+	ret := new({{$.Name}}{{.GoName}}Output)
+{{- else}}
+	var ret struct{}
+{{- end}}
+	out, err := c.contract.Call(ctx, "{{.Name}}", []interface{}{ {{- range $i, $in := .Inputs}}{{if $i}}, {{end}}{{$in.Name}}{{end -}} })
+	if err != nil {
+		return ret, err
+	}
+{{- if eq (len .Outputs) 1}}
+	ret = *abi.ConvertType(out[0], new({{(index .Outputs 0).GoType}})).(*{{(index .Outputs 0).GoType}})
+{{- else}}
+{{- range $i, $out := .Outputs}}
+	ret.{{$out.Name}} = *abi.ConvertType(out[{{$i}}], new({{$out.GoType}})).(*{{$out.GoType}})
+{{- end}}
+{{- end}}
+	return ret, nil
+}
+{{else}}
+// {{.GoName}} sends {{.Name}} as a confidential request and waits for its receipt.
+//
+// Solidity: {{.Signature}}
+func (c *{{$.Name}}) {{.GoName}}(ctx context.Context{{range .Inputs}}, {{.Name}} {{.GoType}}{{end}}, confidentialInput []byte) (*types.Receipt, error) {
+	return c.contract.SendConfidentialRequest(ctx, "{{.Name}}", []interface{}{ {{- range $i, $in := .Inputs}}{{if $i}}, {{end}}{{$in.Name}}{{end -}} }, confidentialInput)
+}
+{{end}}
+{{- end}}
+`))
@@ -0,0 +1,248 @@
+// Command gasgate compares fresh gas measurements against a committed baseline. It exits with
+// status 1 and prints a diff table when the median gas per run of a phase exceeds the baseline by
+// more than the tolerance of the phase. Only the SUAVE rows are gated by default, the L1 gas
+// depends on the state of the public network. The phases listed as ungated in the baseline are
+// printed but never fail the gate.
+//
+//	go run ./cmd/gasgate -baseline gas-baseline.json sweeps/20240101-120000/records.jsonl
+//	go run ./cmd/gasgate -baseline gas-baseline.json -update sweeps/20240101-120000/records.jsonl
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/metrics"
+)
+
+// Baseline is the committed reference of the gas per run.
+type Baseline struct {
+	// DefaultTolerance is the allowed relative increase, 0.02 allows 2% more gas
+	DefaultTolerance float64 `json:"defaultTolerance"`
+	// Tolerances overrides the default per phase
+	Tolerances map[metrics.Phase]float64 `json:"tolerances,omitempty"`
+	// Ungated are the phases whose gas varies too much between runs to be gated, e.g. refute whose
+	// cost depends on the order the bids are revealed in
+	Ungated []metrics.Phase `json:"ungated,omitempty"`
+	Entries []Entry         `json:"entries"`
+}
+
+// Entry is the median gas per run of a phase on a chain.
+type Entry struct {
+	metrics.GroupKey
+	Gas uint64 `json:"gas"`
+}
+
+func (b *Baseline) tolerance(phase metrics.Phase) float64 {
+	if t, ok := b.Tolerances[phase]; ok {
+		return t
+	}
+	return b.DefaultTolerance
+}
+
+const (
+	statusOK        = "ok"
+	statusRegressed = "REGRESSED"
+	statusImproved  = "improved"
+	statusMissing   = "MISSING"
+	statusNew       = "new"
+	statusUngated   = "ungated"
+)
+
+type diff struct {
+	key       metrics.GroupKey
+	baseline  uint64
+	current   uint64
+	tolerance float64
+	status    string
+}
+
+func main() {
+	baselinePath := flag.String("baseline", "gas-baseline.json", "baseline file")
+	update := flag.Bool("update", false, "write the measurements as the new baseline, keeping the tolerances and ungated phases")
+	chainList := flag.String("chains", string(metrics.ChainSuave), "comma separated chains to gate, empty gates all chains")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		log.Fatal("usage: gasgate [flags] <measurements>...")
+	}
+	var records []metrics.Record
+	for _, path := range flag.Args() {
+		r, err := metrics.ReadFile(path, driver.VariantNameByTitle)
+		if err != nil {
+			log.Fatal(err)
+		}
+		records = append(records, r...)
+	}
+	if len(records) == 0 {
+		log.Fatal("no measurements found")
+	}
+	current := medians(records)
+
+	baseline, err := readBaseline(*baselinePath)
+	if *update {
+		if err != nil && !os.IsNotExist(err) {
+			log.Fatal(err)
+		}
+		if err := writeBaseline(*baselinePath, baseline, current); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Baseline %s updated with %d entries\n", *baselinePath, len(current))
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *chainList != "" {
+		chains := map[metrics.Chain]bool{}
+		for _, chain := range strings.Split(*chainList, ",") {
+			chains[metrics.Chain(strings.TrimSpace(chain))] = true
+		}
+		baseline.Entries = slices.DeleteFunc(baseline.Entries, func(e Entry) bool { return !chains[e.Chain] })
+		maps.DeleteFunc(current, func(key metrics.GroupKey, _ uint64) bool { return !chains[key.Chain] })
+	}
+	diffs := compare(baseline, current)
+	printDiffs(diffs)
+	failed := 0
+	for _, d := range diffs {
+		if d.status == statusRegressed || d.status == statusMissing {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("\n%d phases regressed or are missing\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("\nNo gas regressions")
+}
+
+// medians computes the median gas per run of each phase
+func medians(records []metrics.Record) map[metrics.GroupKey]uint64 {
+	result := map[metrics.GroupKey]uint64{}
+	for key, totals := range metrics.GroupByRun(records) {
+		result[key] = uint64(metrics.Summarize(totals).Median + 0.5)
+	}
+	return result
+}
+
+func readBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	baseline := &Baseline{}
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, fmt.Errorf("decoding baseline %s: %w", path, err)
+	}
+	return baseline, nil
+}
+
+func writeBaseline(path string, old *Baseline, current map[metrics.GroupKey]uint64) error {
+	baseline := &Baseline{DefaultTolerance: 0.02}
+	if old != nil {
+		baseline.DefaultTolerance = old.DefaultTolerance
+		baseline.Tolerances = old.Tolerances
+		baseline.Ungated = old.Ungated
+	}
+	keys := make([]metrics.GroupKey, 0, len(current))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	metrics.SortGroupKeys(keys)
+	for _, key := range keys {
+		baseline.Entries = append(baseline.Entries, Entry{GroupKey: key, Gas: current[key]})
+	}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// compare checks the measured phases against the baseline. Only the variants and bidder counts
+// that were measured are compared, so a run with fewer bidders does not report the others as missing.
+func compare(baseline *Baseline, current map[metrics.GroupKey]uint64) []diff {
+	type runKey struct {
+		variant string
+		bidders int
+	}
+	measured := map[runKey]bool{}
+	for key := range current {
+		measured[runKey{key.Variant, key.Bidders}] = true
+	}
+
+	var diffs []diff
+	known := map[metrics.GroupKey]bool{}
+	for _, entry := range baseline.Entries {
+		known[entry.GroupKey] = true
+		if !measured[runKey{entry.Variant, entry.Bidders}] {
+			continue
+		}
+		d := diff{key: entry.GroupKey, baseline: entry.Gas, tolerance: baseline.tolerance(entry.Phase)}
+		gas, ok := current[entry.GroupKey]
+		switch {
+		case !ok:
+			d.status = statusMissing
+		case float64(gas) > float64(entry.Gas)*(1+d.tolerance):
+			d.status = statusRegressed
+		case float64(gas) < float64(entry.Gas)*(1-d.tolerance):
+			d.status = statusImproved
+		default:
+			d.status = statusOK
+		}
+		if ok && slices.Contains(baseline.Ungated, entry.Phase) {
+			d.status = statusUngated
+		}
+		d.current = gas
+		diffs = append(diffs, d)
+	}
+	for key, gas := range current {
+		if !known[key] {
+			diffs = append(diffs, diff{key: key, current: gas, tolerance: baseline.tolerance(key.Phase), status: statusNew})
+		}
+	}
+
+	keys := make([]metrics.GroupKey, len(diffs))
+	index := map[metrics.GroupKey]diff{}
+	for i, d := range diffs {
+		keys[i] = d.key
+		index[d.key] = d
+	}
+	metrics.SortGroupKeys(keys)
+	for i, key := range keys {
+		diffs[i] = index[key]
+	}
+	return diffs
+}
+
+func printDiffs(diffs []diff) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "variant\tphase\tchain\tbidders\tbaseline\tcurrent\tdiff\tdiff %\ttolerance\tstatus\t")
+	for _, d := range diffs {
+		baseline, current, change, percent := "–", "–", "–", "–"
+		if d.status != statusNew {
+			baseline = fmt.Sprint(d.baseline)
+		}
+		if d.status != statusMissing {
+			current = fmt.Sprint(d.current)
+		}
+		if d.status != statusNew && d.status != statusMissing {
+			change = fmt.Sprintf("%+d", int64(d.current)-int64(d.baseline))
+			if d.baseline > 0 {
+				percent = fmt.Sprintf("%+.2f%%", (float64(d.current)/float64(d.baseline)-1)*100)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%.1f%%\t%s\t\n",
+			d.key.Variant, d.key.Phase, d.key.Chain, d.key.Bidders, baseline, current, change, percent, d.tolerance*100, d.status)
+	}
+	w.Flush()
+}
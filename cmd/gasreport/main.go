@@ -0,0 +1,302 @@
+// Command gasreport renders the gas measurements as a self-contained HTML report with inline SVG
+// charts: the gas per phase stacked per bidder count, the split between SUAVE and L1 and a
+// comparison of the SealedAuction and the SealedAuctionProposer.
+//
+//	go run ./cmd/gasreport -o gas-report.html measurements.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/metrics"
+)
+
+func main() {
+	out := flag.String("o", "gas-report.html", "file the report is written to")
+	exclude := flag.String("exclude", "", "comma separated phases left out of the charts, e.g. deploy")
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"measurements.txt"}
+	}
+	var records []metrics.Record
+	for _, path := range paths {
+		r, err := metrics.ReadFile(path, driver.VariantNameByTitle)
+		if err != nil {
+			log.Fatal(err)
+		}
+		records = append(records, r...)
+	}
+	excluded := map[metrics.Phase]bool{}
+	for _, phase := range strings.Split(*exclude, ",") {
+		if phase = strings.TrimSpace(phase); phase != "" {
+			excluded[metrics.Phase(phase)] = true
+		}
+	}
+	var kept []metrics.Record
+	for _, r := range records {
+		if !excluded[r.Phase] {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		log.Fatal("no measurements found")
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	if err := reportTemplate.Execute(file, buildReport(kept, paths)); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Report written to", *out)
+}
+
+// means holds the mean gas per run of each phase and chain
+type means struct {
+	variants []string
+	bidders  []int
+	phases   []metrics.Phase
+	gas      map[metrics.GroupKey]float64
+	runs     map[string]map[int]int
+}
+
+func aggregate(records []metrics.Record) *means {
+	m := &means{gas: map[metrics.GroupKey]float64{}, runs: map[string]map[int]int{}}
+	variants := map[string]bool{}
+	bidders := map[int]bool{}
+	phases := map[metrics.Phase]bool{}
+	for key, totals := range metrics.GroupByRun(records) {
+		m.gas[key] = metrics.Summarize(totals).Mean
+		variants[key.Variant] = true
+		bidders[key.Bidders] = true
+		phases[key.Phase] = true
+	}
+	runIDs := map[string]bool{}
+	for _, r := range records {
+		if runIDs[r.RunID] {
+			continue
+		}
+		runIDs[r.RunID] = true
+		if m.runs[r.Variant] == nil {
+			m.runs[r.Variant] = map[int]int{}
+		}
+		m.runs[r.Variant][r.Bidders]++
+	}
+	for v := range variants {
+		m.variants = append(m.variants, v)
+	}
+	sort.Strings(m.variants)
+	for b := range bidders {
+		m.bidders = append(m.bidders, b)
+	}
+	sort.Ints(m.bidders)
+	for _, phase := range metrics.Phases {
+		if phases[phase] {
+			m.phases = append(m.phases, phase)
+		}
+	}
+	return m
+}
+
+// phase is the mean gas of phase on both chains
+func (m *means) phase(variant string, phase metrics.Phase, bidders int) float64 {
+	return m.chain(variant, phase, metrics.ChainSuave, bidders) + m.chain(variant, phase, metrics.ChainL1, bidders)
+}
+
+func (m *means) chain(variant string, phase metrics.Phase, chain metrics.Chain, bidders int) float64 {
+	return m.gas[metrics.GroupKey{Variant: variant, Phase: phase, Chain: chain, Bidders: bidders}]
+}
+
+func (m *means) total(variant string, bidders int) float64 {
+	sum := 0.0
+	for _, phase := range m.phases {
+		sum += m.phase(variant, phase, bidders)
+	}
+	return sum
+}
+
+func (m *means) bidderLabels() []string {
+	labels := make([]string, len(m.bidders))
+	for i, b := range m.bidders {
+		labels[i] = strconv.Itoa(b)
+	}
+	return labels
+}
+
+type variantSection struct {
+	Name   string
+	Phases barChart
+	Chains barChart
+	Table  table
+}
+
+type table struct {
+	Header []string
+	Rows   [][]string
+}
+
+type report struct {
+	Generated  string
+	Sources    []string
+	Variants   []variantSection
+	Comparison *barChart
+	Phases     []barChart
+	Table      table
+}
+
+func buildReport(records []metrics.Record, sources []string) *report {
+	m := aggregate(records)
+	labels := m.bidderLabels()
+	r := &report{Generated: time.Now().UTC().Format(time.RFC1123), Sources: sources}
+
+	for _, variant := range m.variants {
+		section := variantSection{Name: variant}
+		section.Phases = barChart{Title: "Gas per phase", Labels: labels, XLabel: "bidders", Stacked: true}
+		for _, phase := range m.phases {
+			s := series{Name: string(phase), Values: make([]float64, len(m.bidders))}
+			for i, b := range m.bidders {
+				s.Values[i] = m.phase(variant, phase, b)
+			}
+			section.Phases.Series = append(section.Phases.Series, s)
+		}
+		section.Chains = barChart{Title: "SUAVE and L1 gas", Labels: labels, XLabel: "bidders", Stacked: true}
+		for _, chain := range []metrics.Chain{metrics.ChainSuave, metrics.ChainL1} {
+			s := series{Name: string(chain), Values: make([]float64, len(m.bidders))}
+			for i, b := range m.bidders {
+				for _, phase := range m.phases {
+					s.Values[i] += m.chain(variant, phase, chain, b)
+				}
+			}
+			section.Chains.Series = append(section.Chains.Series, s)
+		}
+
+		section.Table.Header = []string{"phase", "chain"}
+		for _, b := range m.bidders {
+			section.Table.Header = append(section.Table.Header, fmt.Sprintf("%d bidders (%d runs)", b, m.runs[variant][b]))
+		}
+		for _, phase := range m.phases {
+			for _, chain := range []metrics.Chain{metrics.ChainSuave, metrics.ChainL1} {
+				row := []string{string(phase), string(chain)}
+				found := false
+				for _, b := range m.bidders {
+					v := m.chain(variant, phase, chain, b)
+					found = found || v > 0
+					row = append(row, formatCell(v))
+				}
+				if found {
+					section.Table.Rows = append(section.Table.Rows, row)
+				}
+			}
+		}
+		total := []string{"total", ""}
+		for _, b := range m.bidders {
+			total = append(total, formatCell(m.total(variant, b)))
+		}
+		section.Table.Rows = append(section.Table.Rows, total)
+		r.Variants = append(r.Variants, section)
+	}
+
+	if len(m.variants) < 2 {
+		return r
+	}
+	r.Comparison = &barChart{Title: "Total gas per run", Labels: labels, XLabel: "bidders"}
+	for _, variant := range m.variants {
+		s := series{Name: variant, Values: make([]float64, len(m.bidders))}
+		for i, b := range m.bidders {
+			s.Values[i] = m.total(variant, b)
+		}
+		r.Comparison.Series = append(r.Comparison.Series, s)
+	}
+	for _, phase := range m.phases {
+		chart := barChart{Title: string(phase), Labels: labels, XLabel: "bidders"}
+		for _, variant := range m.variants {
+			s := series{Name: variant, Values: make([]float64, len(m.bidders))}
+			for i, b := range m.bidders {
+				s.Values[i] = m.phase(variant, phase, b)
+			}
+			chart.Series = append(chart.Series, s)
+		}
+		r.Phases = append(r.Phases, chart)
+	}
+
+	r.Table.Header = []string{"phase", "bidders"}
+	r.Table.Header = append(r.Table.Header, m.variants...)
+	r.Table.Header = append(r.Table.Header, fmt.Sprintf("%s − %s", m.variants[1], m.variants[0]))
+	for _, phase := range append(append([]metrics.Phase(nil), m.phases...), "") {
+		for _, b := range m.bidders {
+			name := string(phase)
+			value := func(variant string) float64 { return m.phase(variant, phase, b) }
+			if phase == "" {
+				name = "total"
+				value = func(variant string) float64 { return m.total(variant, b) }
+			}
+			row := []string{name, strconv.Itoa(b)}
+			for _, variant := range m.variants {
+				row = append(row, formatCell(value(variant)))
+			}
+			row = append(row, fmt.Sprintf("%+.0f", value(m.variants[1])-value(m.variants[0])))
+			r.Table.Rows = append(r.Table.Rows, row)
+		}
+	}
+	return r
+}
+
+func formatCell(v float64) string {
+	if v == 0 {
+		return "–"
+	}
+	return fmt.Sprintf("%.0f", v)
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Gas report</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 1000px; color: #222; }
+table { border-collapse: collapse; margin: 1em 0 2em; font-size: 13px; }
+th, td { border: 1px solid #ccc; padding: 3px 8px; text-align: right; }
+th:first-child, td:first-child, th:nth-child(2), td:nth-child(2) { text-align: left; }
+.charts { display: flex; flex-wrap: wrap; gap: 1em; }
+.note { color: #666; font-size: 13px; }
+</style>
+</head>
+<body>
+<h1>Gas report</h1>
+<p class="note">Mean gas per run, generated {{.Generated}} from {{range $i, $s := .Sources}}{{if $i}}, {{end}}<code>{{$s}}</code>{{end}}.</p>
+{{range .Variants}}
+<h2>{{.Name}}</h2>
+<div class="charts">{{.Phases.SVG}}{{.Chains.SVG}}</div>
+<table>
+<tr>{{range .Table.Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Table.Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+{{with .Comparison}}
+<h2>Variant comparison</h2>
+{{.SVG}}
+{{end}}
+{{if .Phases}}<div class="charts">{{range .Phases}}{{.SVG}}{{end}}</div>{{end}}
+{{if .Table.Rows}}
+<table>
+<tr>{{range .Table.Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Table.Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
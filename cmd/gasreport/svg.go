@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+var palette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+type series struct {
+	Name   string
+	Values []float64
+}
+
+// barChart is a bar chart with one group of bars per label. The series are stacked on top of each
+// other or drawn next to each other.
+type barChart struct {
+	Title   string
+	Labels  []string
+	XLabel  string
+	Series  []series
+	Stacked bool
+}
+
+const (
+	chartWidth   = 760
+	chartHeight  = 340
+	marginLeft   = 80
+	marginRight  = 170
+	marginTop    = 30
+	marginBottom = 45
+)
+
+// SVG renders the chart as inline SVG.
+func (c barChart) SVG() template.HTML {
+	plotWidth := float64(chartWidth - marginLeft - marginRight)
+	plotHeight := float64(chartHeight - marginTop - marginBottom)
+
+	top := 0.0
+	for i := range c.Labels {
+		sum := 0.0
+		for _, s := range c.Series {
+			if c.Stacked {
+				sum += s.Values[i]
+			} else {
+				sum = math.Max(sum, s.Values[i])
+			}
+		}
+		top = math.Max(top, sum)
+	}
+	top, step := niceScale(top)
+	y := func(v float64) float64 { return marginTop + plotHeight - v/top*plotHeight }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`,
+		chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<text x="%d" y="18" font-size="14" font-weight="bold">%s</text>`, marginLeft, template.HTMLEscapeString(c.Title))
+
+	for v := 0.0; v <= top+step/2; v += step {
+		fmt.Fprintf(&b, `<line x1="%d" x2="%.1f" y1="%.1f" y2="%.1f" stroke="#ddd"/>`, marginLeft, marginLeft+plotWidth, y(v), y(v))
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`, marginLeft-6, y(v), formatGas(v))
+	}
+
+	groupWidth := plotWidth / float64(len(c.Labels))
+	for i, label := range c.Labels {
+		x := marginLeft + groupWidth*float64(i)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`,
+			x+groupWidth/2, marginTop+plotHeight+16, template.HTMLEscapeString(label))
+		if c.Stacked {
+			barWidth := groupWidth * 0.6
+			base := 0.0
+			for j, s := range c.Series {
+				v := s.Values[i]
+				if v <= 0 {
+					continue
+				}
+				fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s</title></rect>`,
+					x+(groupWidth-barWidth)/2, y(base+v), barWidth, y(base)-y(base+v), palette[j%len(palette)],
+					template.HTMLEscapeString(s.Name), formatGas(v))
+				base += v
+			}
+			continue
+		}
+		barWidth := groupWidth * 0.8 / float64(len(c.Series))
+		for j, s := range c.Series {
+			v := s.Values[i]
+			fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s</title></rect>`,
+				x+groupWidth*0.1+barWidth*float64(j), y(v), barWidth, y(0)-y(v), palette[j%len(palette)],
+				template.HTMLEscapeString(s.Name), formatGas(v))
+		}
+	}
+	fmt.Fprintf(&b, `<line x1="%d" x2="%.1f" y1="%.1f" y2="%.1f" stroke="#333"/>`, marginLeft, marginLeft+plotWidth, y(0), y(0))
+	if c.XLabel != "" {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`,
+			marginLeft+plotWidth/2, chartHeight-6, template.HTMLEscapeString(c.XLabel))
+	}
+
+	for j, s := range c.Series {
+		ly := marginTop + 16*j
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%d" width="10" height="10" fill="%s"/>`, marginLeft+plotWidth+16, ly, palette[j%len(palette)])
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" dominant-baseline="middle">%s</text>`, marginLeft+plotWidth+32, ly+5, template.HTMLEscapeString(s.Name))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// niceScale rounds max up to a multiple of a 1, 2 or 5 step with about five grid lines
+func niceScale(max float64) (top, step float64) {
+	if max <= 0 {
+		return 1, 1
+	}
+	raw := max / 5
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step = 10 * magnitude
+	for _, m := range []float64{1, 2, 5} {
+		if raw <= m*magnitude {
+			step = m * magnitude
+			break
+		}
+	}
+	return math.Ceil(max/step) * step, step
+}
+
+// formatGas prints gas amounts like 1.2M or 450k
+func formatGas(v float64) string {
+	switch {
+	case v >= 1e6:
+		return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v/1e6), "0"), ".") + "M"
+	case v >= 1e3:
+		return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.1f", v/1e3), "0"), ".") + "k"
+	default:
+		return fmt.Sprintf("%.0f", v)
+	}
+}
@@ -0,0 +1,157 @@
+// Command gasstats analyses the gas measurements of repeated auction runs. It reads the legacy
+// measurements.txt as well as the JSONL and CSV records and prints per variant, phase and bidder
+// count the mean, median, standard deviation, minimum and maximum, followed by a linear fit of the
+// gas per run against the bidder count.
+//
+//	go run ./cmd/gasstats measurements.txt sweeps/20240101-120000/records.jsonl
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/metrics"
+)
+
+// Row is the summary of one group.
+type Row struct {
+	metrics.GroupKey
+	metrics.Summary
+}
+
+// FitRow is the linear fit of the gas per run of one phase.
+type FitRow struct {
+	Variant string        `json:"variant"`
+	Phase   metrics.Phase `json:"phase"`
+	Chain   metrics.Chain `json:"chain"`
+	metrics.Fit
+}
+
+type Report struct {
+	Summaries []Row    `json:"summaries"`
+	Fits      []FitRow `json:"fits"`
+}
+
+func main() {
+	phases := flag.String("phases", "end,bidding_address,claim", "comma separated phases a linear fit is computed for")
+	totals := flag.Bool("totals", false, "summarize the gas per run instead of per transaction")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"measurements.txt"}
+	}
+	var records []metrics.Record
+	for _, path := range paths {
+		r, err := metrics.ReadFile(path, driver.VariantNameByTitle)
+		if err != nil {
+			log.Fatal(err)
+		}
+		records = append(records, r...)
+	}
+	if len(records) == 0 {
+		log.Fatal("no measurements found")
+	}
+
+	report := analyse(records, strings.Split(*phases, ","), *totals)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	printReport(report, *totals)
+}
+
+func analyse(records []metrics.Record, fitPhases []string, totals bool) *Report {
+	report := &Report{}
+
+	groups := metrics.GroupByTx(records)
+	if totals {
+		groups = metrics.GroupByRun(records)
+	}
+	keys := make([]metrics.GroupKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	metrics.SortGroupKeys(keys)
+	for _, key := range keys {
+		report.Summaries = append(report.Summaries, Row{key, metrics.Summarize(groups[key])})
+	}
+
+	// fit the totals per run, the fixed part is paid once and the marginal part per bidder
+	type series struct {
+		bidders []int
+		gas     []uint64
+	}
+	fitted := map[metrics.GroupKey]*series{}
+	var fitKeys []metrics.GroupKey
+	perRun := metrics.GroupByRun(records)
+	for key, values := range perRun {
+		if !contains(fitPhases, string(key.Phase)) {
+			continue
+		}
+		fitKey := metrics.GroupKey{Variant: key.Variant, Phase: key.Phase, Chain: key.Chain}
+		s, ok := fitted[fitKey]
+		if !ok {
+			s = &series{}
+			fitted[fitKey] = s
+			fitKeys = append(fitKeys, fitKey)
+		}
+		for _, v := range values {
+			s.bidders = append(s.bidders, key.Bidders)
+			s.gas = append(s.gas, v)
+		}
+	}
+	metrics.SortGroupKeys(fitKeys)
+	for _, key := range fitKeys {
+		fit, ok := metrics.FitLinear(fitted[key].bidders, fitted[key].gas)
+		if !ok {
+			continue
+		}
+		report.Fits = append(report.Fits, FitRow{Variant: key.Variant, Phase: key.Phase, Chain: key.Chain, Fit: fit})
+	}
+	return report
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if strings.TrimSpace(item) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func printReport(report *Report, totals bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	if totals {
+		fmt.Println("Gas per run")
+	} else {
+		fmt.Println("Gas per transaction")
+	}
+	fmt.Fprintln(w, "variant\tphase\tchain\tbidders\tn\tmean\tmedian\tstddev\tmin\tmax\t")
+	for _, row := range report.Summaries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%.0f\t%.0f\t%.1f\t%d\t%d\t\n",
+			row.Variant, row.Phase, row.Chain, row.Bidders, row.N, row.Mean, row.Median, row.StdDev, row.Min, row.Max)
+	}
+	w.Flush()
+
+	fmt.Println()
+	fmt.Println("Gas per run = fixed + per bidder * bidders")
+	fmt.Fprintln(w, "variant\tphase\tchain\tn\tfixed\tper bidder\tR²\t")
+	for _, fit := range report.Fits {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%.0f\t%.0f\t%.4f\t\n",
+			fit.Variant, fit.Phase, fit.Chain, fit.N, fit.Fixed, fit.PerBidder, fit.R2)
+	}
+	w.Flush()
+}
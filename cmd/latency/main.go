@@ -0,0 +1,257 @@
+// Command latency summarizes where the time of the auction runs goes. It reads the spans and
+// transaction timings of the JSONL measurements and prints per variant and bidder count the
+// duration of every driver step, the execution and confirmation latency of the transactions and
+// the number of polling rounds, followed by histograms across all runs.
+//
+//	go run ./cmd/latency measurements.jsonl sweeps/20240101-120000/records.jsonl
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/metrics"
+)
+
+type stepKey struct {
+	Variant string `json:"variant"`
+	Step    string `json:"step"`
+	Bidders int    `json:"bidders,omitempty"`
+}
+
+type StepRow struct {
+	stepKey
+	Duration metrics.Summary `json:"durationMs"`
+}
+
+type TxRow struct {
+	metrics.GroupKey
+	Execution    metrics.Summary `json:"executionMs"`
+	Confirmation metrics.Summary `json:"confirmationMs"`
+	PollRounds   metrics.Summary `json:"pollRounds"`
+}
+
+type HistogramRow struct {
+	Name      string            `json:"name"`
+	Histogram metrics.Histogram `json:"histogram"`
+}
+
+type Report struct {
+	Steps        []StepRow      `json:"steps"`
+	Transactions []TxRow        `json:"transactions"`
+	Histograms   []HistogramRow `json:"histograms"`
+}
+
+func main() {
+	histograms := flag.Bool("histograms", true, "print histograms across all runs")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"measurements.jsonl"}
+	}
+	var records []metrics.Record
+	var spans []metrics.Span
+	for _, path := range paths {
+		r, err := metrics.ReadFile(path, driver.VariantNameByTitle)
+		if err != nil {
+			log.Fatal(err)
+		}
+		records = append(records, r...)
+		s, err := metrics.ReadSpansFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		spans = append(spans, s...)
+	}
+
+	report := analyse(records, spans)
+	if len(report.Steps) == 0 && len(report.Transactions) == 0 {
+		log.Fatal("no timings found, they are only recorded in the JSONL and CSV formats")
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	printReport(report, *histograms)
+}
+
+func analyse(records []metrics.Record, spans []metrics.Span) *Report {
+	report := &Report{}
+
+	// steps are sorted in the order they first occur
+	stepIndex := map[string]int{}
+	durations := map[stepKey][]uint64{}
+	overall := map[stepKey][]uint64{}
+	for _, span := range spans {
+		if span.Failed {
+			continue
+		}
+		if _, ok := stepIndex[span.Step]; !ok {
+			stepIndex[span.Step] = len(stepIndex)
+		}
+		d := uint64(max(span.DurationMs, 0))
+		key := stepKey{span.Variant, span.Step, span.Bidders}
+		durations[key] = append(durations[key], d)
+		key.Bidders = 0
+		overall[key] = append(overall[key], d)
+	}
+	stepKeys := make([]stepKey, 0, len(durations))
+	for key := range durations {
+		stepKeys = append(stepKeys, key)
+	}
+	sortStepKeys(stepKeys, stepIndex)
+	for _, key := range stepKeys {
+		report.Steps = append(report.Steps, StepRow{key, metrics.Summarize(durations[key])})
+	}
+
+	type txValues struct{ execution, confirmation, rounds []uint64 }
+	txs := map[metrics.GroupKey]*txValues{}
+	txOverall := map[metrics.GroupKey]*txValues{}
+	add := func(m map[metrics.GroupKey]*txValues, key metrics.GroupKey, r metrics.Record) {
+		v, ok := m[key]
+		if !ok {
+			v = &txValues{}
+			m[key] = v
+		}
+		v.execution = append(v.execution, uint64(max(r.ExecutionMs, 0)))
+		v.confirmation = append(v.confirmation, uint64(max(r.ConfirmationMs, 0)))
+		v.rounds = append(v.rounds, uint64(r.PollRounds))
+	}
+	for _, r := range records {
+		// records written before the timings were added
+		if r.PollRounds == 0 {
+			continue
+		}
+		add(txs, metrics.GroupKey{Variant: r.Variant, Phase: r.Phase, Chain: r.Chain, Bidders: r.Bidders}, r)
+		add(txOverall, metrics.GroupKey{Variant: r.Variant, Phase: r.Phase, Chain: r.Chain}, r)
+	}
+	txKeys := make([]metrics.GroupKey, 0, len(txs))
+	for key := range txs {
+		txKeys = append(txKeys, key)
+	}
+	metrics.SortGroupKeys(txKeys)
+	for _, key := range txKeys {
+		v := txs[key]
+		report.Transactions = append(report.Transactions, TxRow{
+			GroupKey:     key,
+			Execution:    metrics.Summarize(v.execution),
+			Confirmation: metrics.Summarize(v.confirmation),
+			PollRounds:   metrics.Summarize(v.rounds),
+		})
+	}
+
+	overallSteps := make([]stepKey, 0, len(overall))
+	for key := range overall {
+		overallSteps = append(overallSteps, key)
+	}
+	sortStepKeys(overallSteps, stepIndex)
+	for _, key := range overallSteps {
+		report.Histograms = append(report.Histograms, HistogramRow{
+			Name:      fmt.Sprintf("%s %s", key.Variant, key.Step),
+			Histogram: metrics.NewHistogram(metrics.LatencyBuckets, overall[key]),
+		})
+	}
+	overallTxs := make([]metrics.GroupKey, 0, len(txOverall))
+	for key := range txOverall {
+		overallTxs = append(overallTxs, key)
+	}
+	metrics.SortGroupKeys(overallTxs)
+	for _, key := range overallTxs {
+		v := txOverall[key]
+		report.Histograms = append(report.Histograms,
+			HistogramRow{
+				Name:      fmt.Sprintf("%s %s on %s: execution", key.Variant, key.Phase, key.Chain),
+				Histogram: metrics.NewHistogram(metrics.LatencyBuckets, v.execution),
+			},
+			HistogramRow{
+				Name:      fmt.Sprintf("%s %s on %s: confirmation", key.Variant, key.Phase, key.Chain),
+				Histogram: metrics.NewHistogram(metrics.LatencyBuckets, v.confirmation),
+			})
+	}
+	return report
+}
+
+func sortStepKeys(keys []stepKey, stepIndex map[string]int) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.Variant != b.Variant:
+			return a.Variant < b.Variant
+		case a.Step != b.Step:
+			return stepIndex[a.Step] < stepIndex[b.Step]
+		default:
+			return a.Bidders < b.Bidders
+		}
+	})
+}
+
+func printReport(report *Report, histograms bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Println("Duration of the driver steps")
+	fmt.Fprintln(w, "variant\tstep\tbidders\tn\tmean\tmedian\tstddev\tmin\tmax\t")
+	for _, row := range report.Steps {
+		d := row.Duration
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", row.Variant, row.Step, row.Bidders, d.N,
+			seconds(d.Mean), seconds(d.Median), seconds(d.StdDev), seconds(float64(d.Min)), seconds(float64(d.Max)))
+	}
+	w.Flush()
+
+	fmt.Println()
+	fmt.Println("Transaction latency (execution until accepted, confirmation until the receipt was available)")
+	fmt.Fprintln(w, "variant\tphase\tchain\tbidders\tn\texecution mean\texecution max\tconfirmation mean\tconfirmation max\tpoll rounds mean\t")
+	for _, row := range report.Transactions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%.1f\t\n", row.Variant, row.Phase, row.Chain, row.Bidders, row.Execution.N,
+			seconds(row.Execution.Mean), seconds(float64(row.Execution.Max)),
+			seconds(row.Confirmation.Mean), seconds(float64(row.Confirmation.Max)), row.PollRounds.Mean)
+	}
+	w.Flush()
+
+	if !histograms {
+		return
+	}
+	for _, row := range report.Histograms {
+		fmt.Println()
+		fmt.Println(row.Name)
+		printHistogram(row.Histogram)
+	}
+}
+
+func printHistogram(h metrics.Histogram) {
+	const width = 40
+	most := 0
+	for _, c := range h.Counts {
+		most = max(most, c)
+	}
+	for i, c := range h.Counts {
+		label := "> " + seconds(float64(h.Bounds[len(h.Bounds)-1]))
+		if i < len(h.Bounds) {
+			label = "<= " + seconds(float64(h.Bounds[i]))
+		}
+		bar := 0
+		if most > 0 {
+			bar = (c*width + most - 1) / most
+		}
+		fmt.Printf("  %9s | %-*s %d\n", label, width, strings.Repeat("#", bar), c)
+	}
+}
+
+// seconds formats milliseconds
+func seconds(ms float64) string {
+	if ms < 1000 {
+		return fmt.Sprintf("%.0fms", ms)
+	}
+	return fmt.Sprintf("%.1fs", ms/1000)
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// base-auction.jsonl is the Oracle traffic of a base auction with two bidders on Sepolia: the NFT
+// owner check, the end block lookup, the balances of both bidding addresses at that block and the
+// gas price, holder balance, nonce and raw transaction of the NFT transfer. The request bodies are
+// the ones Oracle.sol builds. The account proofs are left out of the eth_getProof responses, the
+// Oracle only reads the balance.
+const fixture = "testdata/base-auction.jsonl"
+
+const (
+	chainID  = "11155111"
+	bidderA  = "0x8a5f0d6e1b2c3d4e5f60718293a4b5c6d7e8f901"
+	bidderB  = "0x1f2e3d4c5b6a79880716253443526170f8e9dacb"
+	holder   = "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23"
+	endBlock = "0x5ccad2"
+	// a re-executed run has new bidding addresses
+	newBidder = "0x00000000000000000000000000000000000000aa"
+)
+
+// the request bodies of Oracle.sol
+func getProofRequest(address, block string) string {
+	return `{"jsonrpc":"2.0", "method": "eth_getProof", "params": ["` + address + `",[],"` + block + `"], "id": "` + chainID + `"}`
+}
+
+func gasPriceRequest() string {
+	return `{"jsonrpc":"2.0", "method": "eth_gasPrice", "params": [], "id": ` + chainID + `}`
+}
+
+func nonceRequest(address string) string {
+	return `{"jsonrpc":"2.0", "method": "eth_getTransactionCount", "params": ["` + address + `", "latest" ], "id": "` + chainID + `"}`
+}
+
+func sendRawRequest(tx string) string {
+	return `{"jsonrpc":"2.0", "method": "eth_sendRawTransaction", "params": ["` + tx + `"], "id": "` + chainID + `"}`
+}
+
+func startReplay(t *testing.T, loose ...string) (*httptest.Server, *replayer) {
+	t.Helper()
+	r, err := loadReplayer(fixture, loose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(&proxy{replayer: r})
+	t.Cleanup(server.Close)
+	return server, r
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func post(t *testing.T, url, body string) (int, string) {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(response)
+}
+
+// recordedRawTx is the signed transfer of the recording, the test cannot sign it again
+func recordedRawTx(t *testing.T, r *replayer) string {
+	t.Helper()
+	for _, e := range r.exchanges {
+		if e.RPCMethod == "eth_sendRawTransaction" {
+			var req struct {
+				Params []string `json:"params"`
+			}
+			if err := json.Unmarshal([]byte(e.Body), &req); err != nil || len(req.Params) != 1 {
+				t.Fatalf("recorded eth_sendRawTransaction %q: %v", e.Body, err)
+			}
+			return req.Params[0]
+		}
+	}
+	t.Fatal("no eth_sendRawTransaction recorded")
+	return ""
+}
+
+func TestReplayRun(t *testing.T) {
+	server, r := startReplay(t)
+	rawTx := recordedRawTx(t, r)
+
+	// the API keys differ from the recording and are not part of the match
+	status, body := get(t, server.URL+"/alchemy/other-key/getOwnersForToken?contractAddress=0x6b175474e89094c44da98b954eedeac495271d0f&tokenId=1")
+	if status != http.StatusOK || !strings.Contains(body, holder) {
+		t.Fatalf("getOwnersForToken: %d %s", status, body)
+	}
+	status, body = get(t, server.URL+"/etherscan?module=block&action=getblocknobytime&timestamp=1718000000&closest=before&apikey=other-key")
+	if status != http.StatusOK || !strings.Contains(body, `"6081234"`) {
+		t.Fatalf("getblocknobytime: %d %s", status, body)
+	}
+	for _, tc := range []struct {
+		name    string
+		request string
+		result  string
+	}{
+		{"balance of bidder B", getProofRequest(bidderB, endBlock), `"balance":"0x71afd498d0000"`},
+		{"balance of bidder A", getProofRequest(bidderA, endBlock), `"balance":"0x38d7ea4c68000"`},
+		{"gas price", gasPriceRequest(), `"id":` + chainID + `,"result":"0x3b9aca00"`},
+		{"balance of the holder", getProofRequest(holder, "latest"), `"balance":"0x2386f26fc10000"`},
+		{"nonce of the holder", nonceRequest(holder), `"result":"0x0"`},
+		{"NFT transfer", sendRawRequest(rawTx), `"result":"0xabab`},
+	} {
+		status, body = post(t, server.URL+"/alchemy/other-key", tc.request)
+		if status != http.StatusOK || !strings.Contains(body, tc.result) {
+			t.Fatalf("%s: %d %s, expected %s", tc.name, status, body, tc.result)
+		}
+	}
+	if n := r.unserved(); n != 0 {
+		t.Fatalf("%d exchanges unserved", n)
+	}
+
+	// every exchange was served, a repeated query has nothing to replay
+	status, _ = post(t, server.URL+"/alchemy/other-key", getProofRequest(bidderA, endBlock))
+	if status != http.StatusBadGateway {
+		t.Fatalf("eth_getProof after the recording: status %d, expected %d", status, http.StatusBadGateway)
+	}
+}
+
+func TestReplayRejectsOtherAddresses(t *testing.T) {
+	server, _ := startReplay(t, "getblocknobytime", "eth_gasPrice")
+	for _, tc := range []struct {
+		name    string
+		request string
+	}{
+		{"balance of a new bidding address", getProofRequest(newBidder, endBlock)},
+		{"balance at another block", getProofRequest(bidderA, "0x5ccad3")},
+		{"nonce of another address", nonceRequest(newBidder)},
+	} {
+		if status, body := post(t, server.URL+"/alchemy/key", tc.request); status != http.StatusBadGateway {
+			t.Fatalf("%s: %d %s, expected status %d", tc.name, status, body, http.StatusBadGateway)
+		}
+	}
+	// the rejected requests did not consume the recorded ones
+	status, body := post(t, server.URL+"/alchemy/key", getProofRequest(bidderA, endBlock))
+	if status != http.StatusOK || !strings.Contains(body, "0x38d7ea4c68000") {
+		t.Fatalf("recorded request: %d %s", status, body)
+	}
+}
+
+func TestLooseMatchingIsOptIn(t *testing.T) {
+	laterEnd := "/etherscan?module=block&action=getblocknobytime&timestamp=1718003600&closest=before&apikey=key"
+
+	server, _ := startReplay(t)
+	if status, _ := get(t, server.URL+laterEnd); status != http.StatusBadGateway {
+		t.Fatalf("getblocknobytime with another timestamp: status %d, expected %d", status, http.StatusBadGateway)
+	}
+
+	server, _ = startReplay(t, "getblocknobytime")
+	status, body := get(t, server.URL+laterEnd)
+	if status != http.StatusOK || !strings.Contains(body, `"6081234"`) {
+		t.Fatalf("loose getblocknobytime: %d %s", status, body)
+	}
+}
+
+func TestLooseRejectsAddressKeyedMethods(t *testing.T) {
+	for method := range addressKeyed {
+		if _, err := loadReplayer(fixture, []string{"getblocknobytime", method}); err == nil {
+			t.Errorf("loose matching of %s accepted", method)
+		}
+	}
+}
+
+func TestParseMethods(t *testing.T) {
+	got := parseMethods(" getblocknobytime,,eth_gasPrice ")
+	if len(got) != 2 || got[0] != "getblocknobytime" || got[1] != "eth_gasPrice" {
+		t.Fatalf("got %q", got)
+	}
+	if got := parseMethods(""); len(got) != 0 {
+		t.Fatalf("got %q for no methods", got)
+	}
+}
+
+func TestUnknownRoute(t *testing.T) {
+	server, _ := startReplay(t)
+	if status, _ := get(t, server.URL+"/infura/key"); status != http.StatusNotFound {
+		t.Fatalf("unknown route: status %d, expected %d", status, http.StatusNotFound)
+	}
+}
+
+func TestExchangeKey(t *testing.T) {
+	p := &proxy{alchemy: "https://alchemy.example/v2/", etherscan: "https://etherscan.example/api"}
+	for _, tc := range []struct {
+		name     string
+		url      string
+		key      string
+		upstream string
+	}{
+		{
+			name:     "alchemy NFT API",
+			url:      "/alchemy/secret/getOwnersForToken?tokenId=1&contractAddress=0x01",
+			key:      "alchemy GET /getOwnersForToken?contractAddress=0x01&tokenId=1 ",
+			upstream: "https://alchemy.example/v2/secret/getOwnersForToken?tokenId=1&contractAddress=0x01",
+		},
+		{
+			name:     "etherscan without API key",
+			url:      "/etherscan?module=block&apikey=secret&action=getblocknobytime",
+			key:      "etherscan GET /?action=getblocknobytime&module=block ",
+			upstream: "https://etherscan.example/api?module=block&apikey=secret&action=getblocknobytime",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			e, upstream, err := p.exchange(req, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.key() != tc.key {
+				t.Errorf("key %q, expected %q", e.key(), tc.key)
+			}
+			if upstream != tc.upstream {
+				t.Errorf("upstream %q, expected %q", upstream, tc.upstream)
+			}
+			if strings.Contains(e.key(), "secret") {
+				t.Errorf("the API key is part of the stored request %q", e.key())
+			}
+		})
+	}
+}
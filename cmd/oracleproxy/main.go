@@ -0,0 +1,211 @@
+// Command oracleproxy records the HTTP traffic of the Oracle contract to Alchemy and Etherscan
+// into a fixture file and replays it, so a captured auction run sees the same balances, blocks and
+// nonces when it is executed again. API keys are forwarded while recording but never stored.
+//
+//	go run ./cmd/oracleproxy -mode record -fixture testdata/oracle-run.jsonl
+//	go run ./cmd/oracleproxy -mode replay -fixture testdata/oracle-run.jsonl
+//
+// Only identical requests are replayed by default. -loose names the methods whose recorded
+// responses may also answer a request with other arguments, e.g. -loose getblocknobytime for the
+// block of a later auction end. Requests keyed by an address, such as eth_getProof and
+// eth_getTransactionCount, are never matched loosely: a re-executed auction has new bidding
+// addresses and must not see the balances recorded for the old ones.
+//
+// Point the Oracle at the proxy with ORACLE_ALCHEMY_URL=http://localhost:8557/alchemy/ and
+// ORACLE_ETHERSCAN_URL=http://localhost:8557/etherscan.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	alchemyPrefix = "/alchemy/"
+	etherscanPath = "/etherscan"
+)
+
+type proxy struct {
+	alchemy   string
+	etherscan string
+	http      *http.Client
+	recorder  *recorder
+	replayer  *replayer
+}
+
+func main() {
+	mode := flag.String("mode", "record", "record the upstream responses or replay them")
+	fixture := flag.String("fixture", "oracle-fixture.jsonl", "JSONL file the exchanges are recorded to or replayed from")
+	listen := flag.String("listen", ":8557", "address the proxy listens on")
+	alchemy := flag.String("alchemy", "https://eth-sepolia.g.alchemy.com/v2/", "upstream Alchemy URL the API key is appended to")
+	etherscan := flag.String("etherscan", "https://api-sepolia.etherscan.io/api", "upstream Etherscan API URL")
+	loose := flag.String("loose", "", "comma separated methods, e.g. getblocknobytime, whose requests with other arguments get the next recorded response of the same method")
+	flag.Parse()
+
+	p := &proxy{alchemy: *alchemy, etherscan: *etherscan, http: &http.Client{Timeout: 10 * time.Second}}
+	switch *mode {
+	case "record":
+		r, err := createRecorder(*fixture)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer r.Close()
+		p.recorder = r
+		log.Printf("recording to %s from %s and %s", *fixture, *alchemy, *etherscan)
+	case "replay":
+		r, err := loadReplayer(*fixture, parseMethods(*loose))
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.replayer = r
+		log.Printf("replaying %d exchanges of %s", len(r.exchanges), *fixture)
+	default:
+		log.Fatalf("unknown mode %q, expected record or replay", *mode)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := &http.Server{Addr: *listen, Handler: p}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+	log.Printf("listening on %s", *listen)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	if p.replayer != nil {
+		if n := p.replayer.unserved(); n > 0 {
+			log.Printf("%d recorded exchanges were not replayed", n)
+		}
+	}
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req, upstream, err := p.exchange(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if p.replayer != nil {
+		recorded, loose, err := p.replayer.match(req)
+		if err != nil {
+			log.Print(err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		if loose {
+			log.Printf("replaying #%d %s %s for a request with other arguments", recorded.Seq, recorded.Route, recorded.RPCMethod)
+		} else {
+			log.Printf("replaying #%d %s %s", recorded.Seq, recorded.Route, recorded.RPCMethod)
+		}
+		writeExchange(w, recorded)
+		return
+	}
+
+	upstreamReq, err := http.NewRequestWithContext(r.Context(), r.Method, upstream, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		upstreamReq.Header.Set("Content-Type", contentType)
+	}
+	resp, err := p.http.Do(upstreamReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	req.Status = resp.StatusCode
+	req.ContentType = resp.Header.Get("Content-Type")
+	req.Response = string(response)
+	if err := p.recorder.record(*req); err != nil {
+		log.Printf("recording %s %s: %v", req.Route, req.RPCMethod, err)
+	} else {
+		log.Printf("recorded %s %s", req.Route, req.RPCMethod)
+	}
+	writeExchange(w, req)
+}
+
+// exchange describes the request without API keys and returns the upstream URL including them
+func (p *proxy) exchange(r *http.Request, body []byte) (*Exchange, string, error) {
+	e := &Exchange{Method: r.Method, Body: string(body)}
+	query := r.URL.Query()
+	switch {
+	case strings.HasPrefix(r.URL.Path, alchemyPrefix):
+		// the Oracle appends the API key and then an optional path, e.g. /getOwnersForToken
+		key, sub, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, alchemyPrefix), "/")
+		e.Route = "alchemy"
+		e.Path = "/" + sub
+		e.Query = query.Encode()
+		upstream := p.alchemy + key
+		if sub != "" {
+			upstream += "/" + sub
+		}
+		if r.URL.RawQuery != "" {
+			upstream += "?" + r.URL.RawQuery
+		}
+		if r.Method == http.MethodPost {
+			var rpc struct {
+				Method string `json:"method"`
+			}
+			if err := json.Unmarshal(body, &rpc); err == nil {
+				e.RPCMethod = rpc.Method
+			}
+		} else {
+			e.RPCMethod = sub
+		}
+		return e, upstream, nil
+	case r.URL.Path == etherscanPath:
+		e.Route = "etherscan"
+		e.Path = "/"
+		e.RPCMethod = query.Get("action")
+		stored := url.Values{}
+		for name, values := range query {
+			if name != "apikey" {
+				stored[name] = values
+			}
+		}
+		e.Query = stored.Encode()
+		upstream := p.etherscan
+		if r.URL.RawQuery != "" {
+			upstream += "?" + r.URL.RawQuery
+		}
+		return e, upstream, nil
+	default:
+		return nil, "", fmt.Errorf("unknown route %s, expected %s<key> or %s", r.URL.Path, alchemyPrefix, etherscanPath)
+	}
+}
+
+func writeExchange(w http.ResponseWriter, e *Exchange) {
+	if e.ContentType != "" {
+		w.Header().Set("Content-Type", e.ContentType)
+	}
+	w.WriteHeader(e.Status)
+	io.WriteString(w, e.Response)
+}
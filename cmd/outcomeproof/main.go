@@ -0,0 +1,128 @@
+// Command outcomeproof exports the outcome of an ended auction as a bundle of eth_getProof account
+// proofs for the revealed bidding addresses at the last L1 block before the end time, and verifies
+// such a bundle offline. Verification needs no RPC access: the headers are checked to enclose the
+// end time, the proofs against the state root and the winner is recomputed from the proven
+// balances. Compare the printed block hash with a block explorer or a node you trust. The bundle
+// does not prove that it lists every revealed bidding address, compare the printed addresses with
+// revealedL1Addresses of the auction on SUAVE.
+//
+//	go run ./cmd/outcomeproof export -journal auction-journal.json -o outcome-proof.json
+//	go run ./cmd/outcomeproof export -variant proposer -auction 0x... -o outcome-proof.json
+//	go run ./cmd/outcomeproof verify outcome-proof.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/journal"
+	"suave/sealedauction/proof"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("usage: outcomeproof export|verify [flags]")
+	}
+	switch os.Args[1] {
+	case "export":
+		export(os.Args[2:])
+	case "verify":
+		verify(os.Args[2:])
+	default:
+		log.Fatalf("unknown command %q, expected export or verify", os.Args[1])
+	}
+}
+
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	journalPath := flags.String("journal", "auction-journal.json", "journal of the run to export")
+	auctionAddress := flags.String("auction", "", "address of the auction on SUAVE, instead of the journaled one")
+	variantName := flags.String("variant", "base", "variant of the auction given with -auction: "+strings.Join(driver.VariantNames(), ", "))
+	out := flags.String("o", "outcome-proof.json", "file the bundle is written to")
+	flags.Parse(args)
+
+	var address common.Address
+	var variant driver.Variant
+	var err error
+	if *auctionAddress != "" {
+		if !common.IsHexAddress(*auctionAddress) {
+			log.Fatalf("invalid auction address %q", *auctionAddress)
+		}
+		address = common.HexToAddress(*auctionAddress)
+		variant, err = driver.VariantByName(*variantName)
+	} else {
+		var j *journal.Journal
+		j, err = journal.Open(*journalPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		address = j.Run().AuctionAddress
+		variant, err = driver.VariantByName(j.Run().Variant)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if address == (common.Address{}) {
+		log.Fatalf("the run in %s has not deployed an auction yet", *journalPath)
+	}
+
+	d, err := driver.New(driver.LoadConfig(), variant)
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, err := d.ExportOutcomeProof(context.Background(), address)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := b.Write(*out); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Exported %d account proofs at L1 block %d to %s\n", len(b.Accounts), b.Outcome.Block, *out)
+	printUnprovenAddresses(b)
+	if !b.Outcome.OK() {
+		// the bundle proves the mismatch, report it like verify does
+		driver.PrintVerification(b.Outcome)
+		fmt.Println("The registered outcome does not match the balances")
+		os.Exit(1)
+	}
+}
+
+func verify(args []string) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		log.Fatal("usage: outcomeproof verify <bundle>")
+	}
+	b, err := proof.Read(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	res, err := b.Verify()
+	if res != nil {
+		fmt.Println("Auction", b.Auction.Hex(), "on L1 chain", b.ChainID)
+		fmt.Println("L1 block", res.Block, "hash", res.BlockHash.Hex(), "state root", res.StateRoot.Hex())
+		driver.PrintVerification(res.Verification)
+	}
+	if err != nil {
+		fmt.Println("Verification failed:", err)
+		os.Exit(1)
+	}
+	fmt.Println("All", len(b.Accounts), "account proofs match the state root and the registered outcome")
+	printUnprovenAddresses(b)
+}
+
+// printUnprovenAddresses lists the bidding addresses of the bundle, whose completeness is not part
+// of the proof
+func printUnprovenAddresses(b *proof.Bundle) {
+	fmt.Println("Not proven: that these", len(b.Outcome.Balances), "addresses are all revealedL1Addresses of the auction on SUAVE")
+	for _, balance := range b.Outcome.Balances {
+		fmt.Println("  ", balance.Address.Hex())
+	}
+}
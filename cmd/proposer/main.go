@@ -0,0 +1,68 @@
+// Command proposer watches a SealedAuctionProposer through its refute period. It waits for the
+// bidding addresses to be revealed, computes the best candidate from their L1 balances at the end
+// of the auction and calls refuteWinner whenever the candidate beats the registered winning bid.
+// It exits once the refute time is over, or right away if nobody bid.
+//
+//	go run ./cmd/proposer -journal auction-journal.json
+//	go run ./cmd/proposer -auction 0x... -interval 10s
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/journal"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func main() {
+	journalPath := flag.String("journal", "auction-journal.json", "journal of the run to watch")
+	auctionAddress := flag.String("auction", "", "address of the auction on SUAVE, instead of the journaled one")
+	interval := flag.Duration("interval", 5*time.Second, "how often the auction is checked")
+	flag.Parse()
+
+	var address common.Address
+	if *auctionAddress != "" {
+		if !common.IsHexAddress(*auctionAddress) {
+			log.Fatalf("invalid auction address %q", *auctionAddress)
+		}
+		address = common.HexToAddress(*auctionAddress)
+	} else {
+		j, err := journal.Open(*journalPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if j.Run().Variant != "proposer" {
+			log.Fatalf("the run in %s is of variant %s, refuting needs the proposer variant", *journalPath, j.Run().Variant)
+		}
+		address = j.Run().AuctionAddress
+		if address == (common.Address{}) {
+			log.Fatalf("the run in %s has not deployed an auction yet", *journalPath)
+		}
+	}
+
+	variant, err := driver.VariantByName("proposer")
+	if err != nil {
+		log.Fatal(err)
+	}
+	d, err := driver.New(driver.LoadConfig(), variant)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	v, err := d.RunProposer(ctx, address, *interval)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if v != nil {
+		driver.PrintVerification(v)
+	}
+}
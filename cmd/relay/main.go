@@ -0,0 +1,104 @@
+// Command relay broadcasts the L1 transactions the OracleProposer signs but does not send. It
+// follows the EncodedTx logs of the given oracles on SUAVE, validates each transaction (chain ID,
+// recipient, the nonce and balance of its sender on L1), broadcasts it, tracks its inclusion and
+// sends it again if it is dropped. It runs until it is interrupted.
+//
+// With the journal, every transaction must be the NFT transfer or an ETH transfer of the journaled
+// auction. Oracles given with -oracle are not tied to an auction and need the accepted recipients.
+//
+//	go run ./cmd/relay -journal auction-journal.json -from 1234
+//	go run ./cmd/relay -oracle 0x...,0x... -to 0x...
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/journal"
+	"suave/sealedauction/relay"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func main() {
+	journalPath := flag.String("journal", "auction-journal.json", "journal of the run whose oracle is followed")
+	oracleList := flag.String("oracle", "", "comma separated oracle addresses on SUAVE, instead of the journaled one")
+	toList := flag.String("to", "", "comma separated L1 recipients to accept, required with -oracle")
+	from := flag.Uint64("from", 0, "SUAVE block to start reading logs at, 0 for the current block")
+	interval := flag.Duration("interval", 5*time.Second, "how often SUAVE and L1 are checked")
+	flag.Parse()
+
+	var oracles []common.Address
+	var auctions map[common.Address]*relay.Auction
+	if *oracleList != "" {
+		if *toList == "" {
+			log.Fatal("-oracle is not tied to an auction, pass the accepted recipients with -to")
+		}
+		var err error
+		if oracles, err = parseAddresses(*oracleList); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		j, err := journal.Open(*journalPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if j.Run().Variant != "proposer" {
+			log.Fatalf("the run in %s is of variant %s, only the proposer variant emits EncodedTx", *journalPath, j.Run().Variant)
+		}
+		if j.Run().OracleAddress == (common.Address{}) {
+			log.Fatalf("the run in %s has not deployed an oracle yet", *journalPath)
+		}
+		if j.Run().NFTHoldingAddress == (common.Address{}) {
+			log.Fatalf("the run in %s has no NFT holding address yet", *journalPath)
+		}
+		oracles = []common.Address{j.Run().OracleAddress}
+		auctions = map[common.Address]*relay.Auction{j.Run().OracleAddress: {
+			NFTContract: j.Run().NFTContractAddress,
+			TokenID:     j.Run().NFTTokenID,
+			NFTHolder:   j.Run().NFTHoldingAddress,
+		}}
+	}
+	var recipients []common.Address
+	if *toList != "" {
+		var err error
+		if recipients, err = parseAddresses(*toList); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	variant, err := driver.VariantByName("proposer")
+	if err != nil {
+		log.Fatal(err)
+	}
+	d, err := driver.New(driver.LoadConfig(), variant)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := d.RunRelay(ctx, oracles, auctions, *from, recipients, *interval); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+}
+
+func parseAddresses(list string) ([]common.Address, error) {
+	var addresses []common.Address
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address %q", s)
+		}
+		addresses = append(addresses, common.HexToAddress(s))
+	}
+	return addresses, nil
+}
@@ -0,0 +1,205 @@
+// Command standin emulates the subset of the Alchemy and Etherscan APIs the Oracle contract uses on
+// top of a local L1 node, so the auction can be run without external services or API keys. The
+// Alchemy JSON-RPC methods are forwarded to the node, the NFT owner and block by timestamp queries
+// are answered from its state. Any API key appended to the URLs is ignored.
+//
+//	go run ./cmd/standin -listen :8556 -l1 http://localhost:8555
+//
+// Point the Oracle at it with ORACLE_ALCHEMY_URL=http://localhost:8556/ and
+// ORACLE_ETHERSCAN_URL=http://localhost:8556/api, the defaults of L1_NETWORK=local.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"suave/sealedauction/l1"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// forwarded are the JSON-RPC methods the Oracle sends to Alchemy
+var forwarded = map[string]bool{
+	"eth_chainId":             true,
+	"eth_blockNumber":         true,
+	"eth_gasPrice":            true,
+	"eth_getBalance":          true,
+	"eth_getProof":            true,
+	"eth_getTransactionCount": true,
+	"eth_sendRawTransaction":  true,
+}
+
+const erc721ABI = `[{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"ownerOf","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"}]`
+
+type server struct {
+	l1URL  string
+	l1     *ethclient.Client
+	http   *http.Client
+	erc721 abi.ABI
+}
+
+func main() {
+	listen := flag.String("listen", ":8556", "address the stand-in listens on")
+	l1URL := flag.String("l1", "http://localhost:8555", "RPC of the local L1 node")
+	flag.Parse()
+
+	client, err := ethclient.Dial(*l1URL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	erc721, err := abi.JSON(strings.NewReader(erc721ABI))
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := &server{
+		l1URL:  *l1URL,
+		l1:     client,
+		http:   &http.Client{Timeout: 10 * time.Second},
+		erc721: erc721,
+	}
+	log.Printf("emulating Alchemy and Etherscan for %s on %s", *l1URL, *listen)
+	log.Fatal(http.ListenAndServe(*listen, s))
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost:
+		s.serveRPC(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/api":
+		s.serveEtherscan(w, r)
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/getOwnersForToken"):
+		s.serveOwners(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveRPC forwards the supported JSON-RPC methods to the L1 node
+func (s *server) serveRPC(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, "invalid JSON-RPC request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Printf("rpc %s", req.Method)
+	if !forwarded[req.Method] {
+		writeJSON(w, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"error":   map[string]interface{}{"code": -32601, "message": fmt.Sprintf("the method %s is not supported by the stand-in", req.Method)},
+		})
+		return
+	}
+	resp, err := s.http.Post(s.l1URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		log.Printf("rpc %s: %v", req.Method, err)
+	}
+}
+
+// serveEtherscan answers module=block&action=getblocknobytime like the Etherscan API
+func (s *server) serveEtherscan(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("module") != "block" || query.Get("action") != "getblocknobytime" {
+		writeJSON(w, etherscanResponse{Status: "0", Message: "NOTOK", Result: "Error! Unsupported module or action"})
+		return
+	}
+	timestamp, err := strconv.ParseUint(query.Get("timestamp"), 10, 64)
+	if err != nil {
+		writeJSON(w, etherscanResponse{Status: "0", Message: "NOTOK", Result: "Error! Invalid timestamp"})
+		return
+	}
+	before := query.Get("closest") != "after"
+	number, ok, err := l1.BlockAtTime(r.Context(), s.l1, timestamp, before)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	log.Printf("getblocknobytime %d closest=%s: %d (found %t)", timestamp, query.Get("closest"), number, ok)
+	if !ok {
+		writeJSON(w, etherscanResponse{Status: "0", Message: "NOTOK", Result: "Error! No closest block found"})
+		return
+	}
+	writeJSON(w, etherscanResponse{Status: "1", Message: "OK", Result: strconv.FormatUint(number, 10)})
+}
+
+type etherscanResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Result  string `json:"result"`
+}
+
+// serveOwners answers the getOwnersForToken query of the Alchemy NFT API with ownerOf
+func (s *server) serveOwners(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	contract := query.Get("contractAddress")
+	tokenID, ok := new(big.Int).SetString(query.Get("tokenId"), 0)
+	if !common.IsHexAddress(contract) || !ok {
+		http.Error(w, "contractAddress and tokenId are required", http.StatusBadRequest)
+		return
+	}
+	owner, err := s.ownerOf(r.Context(), common.HexToAddress(contract), tokenID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	log.Printf("getOwnersForToken %s %s: %s", contract, tokenID, owner.Hex())
+	writeJSON(w, map[string]interface{}{
+		"owners":  []string{strings.ToLower(owner.Hex())},
+		"pageKey": nil,
+	})
+}
+
+func (s *server) ownerOf(ctx context.Context, contract common.Address, tokenID *big.Int) (common.Address, error) {
+	data, err := s.erc721.Pack("ownerOf", tokenID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	output, err := s.l1.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	res, err := s.erc721.Unpack("ownerOf", output)
+	if err != nil {
+		return common.Address{}, err
+	}
+	owner, ok := res[0].(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("ownerOf: unexpected output type %T", res[0])
+	}
+	return owner, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
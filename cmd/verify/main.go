@@ -0,0 +1,76 @@
+// Command verify recomputes the winner of an ended auction independently of the oracle. It reads
+// the revealed bidding addresses and the end time from SUAVE, searches the L1 block at the end time
+// and compares the balances at that block with the registered winner, winning bid and the
+// minimalBid fallback. It exits with status 1 if they do not match.
+//
+//	go run ./cmd/verify -journal auction-journal.json
+//	go run ./cmd/verify -variant proposer -auction 0x...
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"suave/sealedauction/driver"
+	"suave/sealedauction/journal"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func main() {
+	journalPath := flag.String("journal", "auction-journal.json", "journal of the run to verify")
+	auctionAddress := flag.String("auction", "", "address of the auction on SUAVE, instead of the journaled one")
+	variantName := flag.String("variant", "base", "variant of the auction given with -auction: "+strings.Join(driver.VariantNames(), ", "))
+	asJSON := flag.Bool("json", false, "print the verification as JSON")
+	flag.Parse()
+
+	var address common.Address
+	var variant driver.Variant
+	var err error
+	if *auctionAddress != "" {
+		if !common.IsHexAddress(*auctionAddress) {
+			log.Fatalf("invalid auction address %q", *auctionAddress)
+		}
+		address = common.HexToAddress(*auctionAddress)
+		variant, err = driver.VariantByName(*variantName)
+	} else {
+		var j *journal.Journal
+		j, err = journal.Open(*journalPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		address = j.Run().AuctionAddress
+		variant, err = driver.VariantByName(j.Run().Variant)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if address == (common.Address{}) {
+		log.Fatalf("the run in %s has not deployed an auction yet", *journalPath)
+	}
+
+	d, err := driver.New(driver.LoadConfig(), variant)
+	if err != nil {
+		log.Fatal(err)
+	}
+	v, err := d.VerifyWinner(context.Background(), address)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		driver.PrintVerification(v)
+	}
+	if !v.OK() {
+		os.Exit(1)
+	}
+}
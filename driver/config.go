@@ -0,0 +1,159 @@
+package driver
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"suave/sealedauction/framework"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/joho/godotenv"
+)
+
+const (
+	SEPOLIA_CHAIN_ID       = 11155111
+	HOLESKY_CHAIN_ID       = 17000
+	SUAVE_TESTNET_CHAIN_ID = 16813125
+)
+
+type Config struct {
+	// DEPRECATED: for toliman suave chain use https://rpc.toliman.suave.flashbots.net
+	SuaveRPC string
+	L1RPC    string
+	// the L1 profile; the chain ID is detected from L1RPC and checked against it
+	Network Network
+
+	// the accounts default to the ones funded on the devnets of the framework if nil
+	SuaveDevAccount *framework.PrivKey
+	L1DevAccount    *framework.PrivKey
+
+	// the NFT to be auctioned, owned by L1DevAccount; in local mode a TestNFT is deployed if unset
+	NFTContractAddress common.Address
+	NFTTokenID         *big.Int
+
+	AlchemyApiKey   string
+	EtherscanApiKey string
+	// endpoints the Oracle queries; other ones than the compiled-in ones deploy the TestOracle
+	OracleAlchemyURL   string
+	OracleEtherscanURL string
+
+	// file the gas costs are recorded to, as JSONL or CSV depending on the extension; empty disables it
+	MetricsPath string
+	// additionally append the gas costs to measurements.txt in the legacy text format
+	WriteToFile bool
+
+	// deadline for every phase of the auction (deploy, setup, each bid, ...); 0 disables it
+	PhaseTimeout time.Duration
+
+	// number of bidders that are funded and bid at the same time; values below 1 bid sequentially
+	BidConcurrency int
+
+	// derive the bidder keys from this seed to make runs reproducible; 0 generates random keys
+	Seed int64
+	// SeedRun is mixed into the derivation, so runs with the same seed use different keys
+	SeedRun int64
+
+	// file the progress of a run is journaled to, see the journal package
+	JournalPath string
+
+	// contract artifacts baked into the binary; ARTIFACT_DIR and out/ of the checkout are used if nil
+	Artifacts fs.FS
+}
+
+// LoadConfig reads the configuration from the .env file, see .env.example. With L1_NETWORK=local
+// the keys default to the accounts funded on the devnets and a TestNFT is deployed to auction.
+func LoadConfig() *Config {
+	err := godotenv.Load()
+	// a local run can be configured entirely with environment variables
+	if err != nil && !(errors.Is(err, fs.ErrNotExist) && os.Getenv("L1_NETWORK") != "") {
+		log.Fatal("Error loading .env file: ", err)
+	}
+	networkName := os.Getenv("L1_NETWORK")
+	if networkName == "" {
+		networkName = "sepolia"
+	}
+	network, err := NetworkByName(networkName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	config := &Config{
+		SuaveRPC:           "http://localhost:8545",
+		Network:            network,
+		OracleAlchemyURL:   network.AlchemyURL,
+		OracleEtherscanURL: network.EtherscanURL,
+		MetricsPath:        "measurements.jsonl",
+		WriteToFile:        true,
+		JournalPath:        "auction-journal.json",
+		BidConcurrency:     1,
+	}
+	if url := os.Getenv("ORACLE_ALCHEMY_URL"); url != "" {
+		config.OracleAlchemyURL = url
+	}
+	if url := os.Getenv("ORACLE_ETHERSCAN_URL"); url != "" {
+		config.OracleEtherscanURL = url
+	}
+
+	// in local mode the accounts funded on the devnets are used, see framework.Config
+	privKeySuave := os.Getenv("SUAVE_DEV_PRIVATE_KEY")
+	if privKeySuave != "" {
+		config.SuaveDevAccount = framework.NewPrivKeyFromHex(privKeySuave)
+	} else if !network.Local {
+		log.Fatal("ENTER PRIVATE Suave KEY in .env file!")
+	}
+	privKey := os.Getenv("L1_PRIVATE_KEY")
+	if privKey != "" {
+		config.L1DevAccount = framework.NewPrivKeyFromHex(privKey)
+	} else if !network.Local {
+		log.Fatal("ENTER PRIVATE L1 KEY in .env file!")
+	}
+
+	config.L1RPC = os.Getenv("L1_RPC")
+	if config.L1RPC == "" {
+		config.L1RPC = network.RPC
+		if network.needsAPIKey {
+			infuraApiKey := os.Getenv("INFURA_API_KEY")
+			if infuraApiKey == "" {
+				// the name used before other networks than Sepolia were supported
+				infuraApiKey = os.Getenv("SEPOLIA_API_KEY")
+			}
+			if infuraApiKey == "" {
+				log.Fatal("ENTER your INFURA_API_KEY or an L1_RPC in .env file!")
+			}
+			config.L1RPC += infuraApiKey
+		}
+	}
+
+	// in local mode a TestNFT is deployed for every run if no NFT is configured
+	nftAddressString := os.Getenv("NFT_CONTRACT_ADDRESS")
+	if nftAddressString == "" && !network.Local {
+		log.Fatal("ENTER NFT_CONTRACT_ADDRESS in .env file!")
+	}
+	if nftAddressString != "" {
+		tokenIDString := os.Getenv("NFT_TOKEN_ID")
+		if tokenIDString == "" {
+			log.Fatal("ENTER NFT_TOKEN_ID in .env file!")
+		}
+		tokenIDUint, err := strconv.ParseUint(tokenIDString, 10, 64)
+		if err != nil {
+			log.Fatal("NFT_TOKEN_ID is not a valid token ID: ", err)
+		}
+		config.NFTContractAddress = common.HexToAddress(nftAddressString)
+		config.NFTTokenID = new(big.Int).SetUint64(tokenIDUint)
+	}
+
+	// the services emulating Alchemy and Etherscan on a local L1 need no keys
+	config.AlchemyApiKey = os.Getenv("ALCHEMY_API_KEY")
+	if config.AlchemyApiKey == "" && !network.Local {
+		log.Fatal("ENTER ALCHEMY_API_KEY in .env file!")
+	}
+	config.EtherscanApiKey = os.Getenv("ETHERSCAN_API_KEY")
+	if config.EtherscanApiKey == "" && !network.Local {
+		log.Fatal("ENTER ETHERSCAN_API_KEY in .env file!")
+	}
+	return config
+}
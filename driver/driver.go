@@ -0,0 +1,829 @@
+// Package driver simulates a complete sealed auction: it deploys the contracts on SUAVE,
+// moves the NFT on L1, places bids with freshly created accounts and claims all valuables.
+package driver
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"suave/sealedauction/auction"
+	"suave/sealedauction/framework"
+	"suave/sealedauction/journal"
+	"suave/sealedauction/l1"
+	"suave/sealedauction/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/suave/sdk"
+)
+
+type Driver struct {
+	config   *Config
+	variant  Variant
+	fr       *framework.Framework
+	journal  *journal.Journal
+	recorder *metrics.Recorder
+	l1Txs    *l1.Manager
+
+	SuaveClient     *ethclient.Client
+	L1client        *ethclient.Client
+	L1chainID       *big.Int
+	L1DevAccount    *framework.PrivKey
+	SuaveDevAccount *framework.PrivKey
+}
+
+func New(config *Config, variant Variant) (*Driver, error) {
+	suaveClient, err := ethclient.Dial(config.SuaveRPC)
+	if err != nil {
+		return nil, fmt.Errorf("dialing SUAVE: %w", err)
+	}
+	opts := []framework.ConfigOption{framework.WithL1()}
+	if config.Artifacts != nil {
+		opts = append(opts, framework.WithArtifacts(config.Artifacts))
+	}
+	fr, err := framework.New(opts...)
+	if err != nil {
+		suaveClient.Close()
+		return nil, err
+	}
+	var l1client *ethclient.Client
+	if config.Network.Local {
+		// the L1 devnet of the framework, see L1_RPC
+		l1client = fr.L1.RPC()
+	} else {
+		l1client, err = ethclient.Dial(config.L1RPC)
+		if err != nil {
+			suaveClient.Close()
+			fr.Close()
+			return nil, fmt.Errorf("dialing L1: %w", err)
+		}
+	}
+	l1ChainID, err := detectChainID(l1client, config.Network)
+	if err != nil {
+		suaveClient.Close()
+		l1client.Close()
+		fr.Close()
+		return nil, err
+	}
+	d := &Driver{
+		config:          config,
+		variant:         variant,
+		fr:              fr,
+		l1Txs:           l1.NewManager(l1client, l1ChainID, l1.DefaultFeePolicy()),
+		SuaveClient:     suaveClient,
+		L1client:        l1client,
+		L1chainID:       l1ChainID,
+		L1DevAccount:    config.L1DevAccount,
+		SuaveDevAccount: config.SuaveDevAccount,
+	}
+	if d.L1DevAccount == nil {
+		d.L1DevAccount = fr.FundedAccountL1()
+	}
+	if d.SuaveDevAccount == nil {
+		d.SuaveDevAccount = fr.FundedAccount()
+	}
+	return d, nil
+}
+
+// Close closes the connections to SUAVE and L1.
+func (d *Driver) Close() {
+	d.SuaveClient.Close()
+	d.L1client.Close()
+	d.fr.Close()
+}
+
+// Run simulates an auction with num_bidder bidders from deployment until every party claimed.
+// Every step is recorded in the journal at config.JournalPath, so an aborted run can be continued
+// with Resume. Cancelling ctx aborts the run after the current request.
+func (d *Driver) Run(ctx context.Context, num_bidder int) error {
+	nftContractAddress, nftTokenID := d.config.NFTContractAddress, d.config.NFTTokenID
+	if nftContractAddress == (common.Address{}) {
+		if !d.config.Network.Local {
+			return fmt.Errorf("no NFT to auction configured")
+		}
+		var err error
+		nftContractAddress, nftTokenID, err = d.deployTestNFT(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	j, err := journal.Create(d.config.JournalPath, journal.Run{
+		ID:                 metrics.NewRunID(),
+		Variant:            d.variant.Name(),
+		NumBidder:          num_bidder,
+		StartedAt:          time.Now(),
+		NFTContractAddress: nftContractAddress,
+		NFTTokenID:         nftTokenID,
+		Seed:               d.config.Seed,
+		SeedRun:            d.config.SeedRun,
+	})
+	if err != nil {
+		return err
+	}
+	d.journal = j
+	if err := d.openRecorder(false); err != nil {
+		return err
+	}
+	defer d.recorder.Close()
+	return d.procedure(ctx)
+}
+
+// Resume continues the run recorded in j after the last completed phase. The driver must have
+// been created with the variant of the journaled run.
+func (d *Driver) Resume(ctx context.Context, j *journal.Journal) error {
+	run := j.Run()
+	if run.Variant != d.variant.Name() {
+		return fmt.Errorf("journal %s is a run of variant %s, not %s", j.Path(), run.Variant, d.variant.Name())
+	}
+	if run.Phase == journal.PhaseClaimed && len(run.Unclaimed()) == 0 {
+		return fmt.Errorf("run in journal %s has already finished", j.Path())
+	}
+	fmt.Println("Resuming the", d.variant.Title(), "after phase:", run.Phase)
+	d.journal = j
+	if err := d.openRecorder(true); err != nil {
+		return err
+	}
+	defer d.recorder.Close()
+	return d.procedure(ctx)
+}
+
+// openRecorder opens the configured measurement sinks for the journaled run
+func (d *Driver) openRecorder(resumed bool) error {
+	var sinks []metrics.Sink
+	if d.config.MetricsPath != "" {
+		sink, err := metrics.Open(d.config.MetricsPath)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+	if d.config.WriteToFile {
+		sink, err := metrics.Open("measurements.txt")
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+	run := d.journal.Run()
+	recorder, err := metrics.NewRecorder(metrics.Run{
+		ID:        run.ID,
+		Variant:   run.Variant,
+		Title:     d.variant.Title(),
+		Bidders:   run.NumBidder,
+		StartedAt: run.StartedAt,
+		Resumed:   resumed,
+	}, sinks...)
+	if err != nil {
+		return err
+	}
+	d.recorder = recorder
+	return nil
+}
+
+func (d *Driver) procedure(ctx context.Context) error {
+	ctx = framework.WithTxObserver(ctx, d.observeTx)
+	gasPrice, err := d.SuaveClient.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Current Suave Toliman Gas Price: ", gasPrice)
+
+	run := d.journal.Run()
+	num_bidder := run.NumBidder
+	// a round of concurrent bids takes about a minute
+	concurrency := max(1, min(d.config.BidConcurrency, num_bidder))
+	rounds := (num_bidder + concurrency - 1) / concurrency
+	auctionInSeconds := int64(rounds*60 + 60)
+	nftTokenID := run.NFTTokenID
+	nftContractAddress := run.NFTContractAddress
+	var client *auction.Client
+	if d.journal.Reached(journal.PhaseDeployed) {
+		client, err = d.variant.Attach(d, run.AuctionAddress)
+		if err != nil {
+			return err
+		}
+	} else {
+		auctionEndTime := big.NewInt(int64(time.Now().Unix() + auctionInSeconds))
+		err = d.phase(ctx, "deploy", func(ctx context.Context) (err error) {
+			client, err = d.variant.Deploy(ctx, d, DeployParams{
+				NFTContractAddress: nftContractAddress,
+				NFTTokenID:         nftTokenID,
+				AuctionEndTime:     auctionEndTime,
+				MinimalBid:         big.NewInt(1000000000), // 1 GWEI
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		oracle, err := client.Oracle(ctx)
+		if err != nil {
+			return err
+		}
+		err = d.journal.Update(func(run *journal.Run) {
+			run.AuctionAddress = client.Address()
+			run.OracleAddress = oracle
+			run.AuctionEndTime = auctionEndTime
+			run.Phase = journal.PhaseDeployed
+		})
+		if err != nil {
+			return err
+		}
+	}
+	machine := auction.NewStateMachine(client, d.nftOwner)
+
+	fmt.Println("2 Setup Auction")
+	err = d.step(ctx, journal.PhaseSetUp, "setup", func(ctx context.Context) error {
+		nftHoldingAddress, err := d.setUpAuction(ctx, client)
+		if err != nil {
+			return err
+		}
+		return d.journal.Update(func(run *journal.Run) { run.NFTHoldingAddress = nftHoldingAddress })
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("3. Moving the NFT from auctioneer to holding address")
+	err = d.step(ctx, journal.PhaseNFTMoved, "move NFT", func(ctx context.Context) error {
+		nftHoldingAddress := d.journal.Run().NFTHoldingAddress
+		// the transfer might have been mined before the last run was aborted
+		owner, err := d.nftOwner(ctx, nftContractAddress, nftTokenID)
+		if err != nil {
+			return err
+		}
+		if owner == nftHoldingAddress {
+			fmt.Println("NFT is already owned by the holding address")
+			return nil
+		}
+		return d.moveNft(ctx, nftHoldingAddress, nftTokenID, nftContractAddress, d.L1DevAccount)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("4. Start Auction")
+	err = d.step(ctx, journal.PhaseStarted, "start", func(ctx context.Context) error {
+		snapshot, err := machine.Snapshot(ctx)
+		if err != nil {
+			return err
+		}
+		if snapshot.HasStarted {
+			fmt.Println("Auction has already started")
+			return nil
+		}
+		return d.startAuction(ctx, client)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("5. Place bid with ", num_bidder, " accounts")
+	if !d.journal.Reached(journal.PhaseBidding) {
+		if err := d.placeBids(ctx, num_bidder, concurrency, client, machine); err != nil {
+			return err
+		}
+		if err := d.journal.Complete(journal.PhaseBidding); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Waiting for the auction to be over.")
+	err = d.timed(ctx, "await end", func(ctx context.Context) error {
+		_, err := machine.Await(ctx, auction.TransitionEnd)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("6. End Auction")
+	err = d.step(ctx, journal.PhaseEnded, "end", func(ctx context.Context) error {
+		if err := d.endAuction(ctx, client); err != nil {
+			return err
+		}
+		return printOutcome(ctx, client)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = d.step(ctx, journal.PhaseFinalized, "finalize", func(ctx context.Context) error {
+		placed := 0
+		for _, bidder := range d.journal.Run().Bidders {
+			if bidder.BidPlaced {
+				placed++
+			}
+		}
+		return d.variant.Finalize(ctx, d, client, placed)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = d.timed(ctx, "await claim", func(ctx context.Context) error {
+		_, err := machine.Await(ctx, auction.TransitionClaim)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	// the winner is final once the valuables can be claimed
+	d.verifyWinner(ctx, client)
+
+	fmt.Println("7b. Claim: Get winning bid as auctioneer")
+	if !d.journal.Run().AuctioneerClaimed {
+		var claimed bool
+		if err := d.phase(ctx, "claim", func(ctx context.Context) (err error) { claimed, err = d.claim(ctx, client); return err }); err != nil {
+			return err
+		}
+		if err := d.journal.Update(func(run *journal.Run) { run.AuctioneerClaimed = claimed }); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("7a. Claim: get NFT for winner & return bids")
+	for _, bidder := range d.journal.Run().Bidders {
+		if bidder.Claimed || !bidder.BidPlaced {
+			continue
+		}
+		privKey := framework.NewPrivKeyFromHex(bidder.PrivateKey)
+		var claimed bool
+		if err := d.phase(ctx, "claim", func(ctx context.Context) (err error) { claimed, err = d.claim(ctx, client.Ref(privKey)); return err }); err != nil {
+			return err
+		}
+		if err := d.journal.Update(func(run *journal.Run) { bidder.Claimed = claimed }); err != nil {
+			return err
+		}
+	}
+	// the journal holds the only copy of the bidder keys, keep the run resumable until every
+	// claim went through
+	if unclaimed := d.journal.Run().Unclaimed(); len(unclaimed) > 0 {
+		return fmt.Errorf("the claims of %s failed, resume the run to retry them", strings.Join(unclaimed, ", "))
+	}
+	return d.journal.Complete(journal.PhaseClaimed)
+}
+
+// step runs a phase unless the journal records it as completed and marks it completed afterwards
+func (d *Driver) step(ctx context.Context, phase journal.Phase, name string, fn func(ctx context.Context) error) error {
+	if d.journal.Reached(phase) {
+		fmt.Println("Skipping", name, "- already completed")
+		return nil
+	}
+	if err := d.phase(ctx, name, fn); err != nil {
+		return err
+	}
+	return d.journal.Complete(phase)
+}
+
+// placeBids bids with num_bidder bidders using up to concurrency workers. Once a bid failed or
+// the bidding is over no further bids are started; the bids in flight are finished and all
+// errors are reported together.
+func (d *Driver) placeBids(ctx context.Context, num_bidder, concurrency int, client *auction.Client, machine *auction.StateMachine) error {
+	if err := d.createBidders(num_bidder); err != nil {
+		return err
+	}
+	var (
+		mu     sync.Mutex
+		errs   []error
+		closed bool
+	)
+	halted := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return closed || len(errs) > 0
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				err := d.bid(ctx, i, client, machine)
+				if err == nil {
+					continue
+				}
+				mu.Lock()
+				if errors.Is(err, auction.ErrTransitionNotAllowed) {
+					if !closed {
+						fmt.Println("Not placing further bids:", err)
+					}
+					closed = true
+				} else {
+					errs = append(errs, fmt.Errorf("bidder #%d: %w", i, err))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+dispatch:
+	for i := range num_bidder {
+		if halted() {
+			break
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if len(errs) == 0 {
+		return ctx.Err()
+	}
+	return errors.Join(errs...)
+}
+
+// createBidders journals the keys of all bidders before any of them is funded, so concurrent
+// bids never race for a slot in the journal.
+func (d *Driver) createBidders(num_bidder int) error {
+	var created []*journal.Bidder
+	for i := len(d.journal.Run().Bidders); i < num_bidder; i++ {
+		fmt.Println("Creating account #", i)
+		privKey, err := d.bidderKey(i)
+		if err != nil {
+			return err
+		}
+		log.Printf("Created Address at: %s", privKey.Address().Hex())
+		bidder := &journal.Bidder{PrivateKey: hex.EncodeToString(privKey.MarshalPrivKey()), Address: privKey.Address()}
+		fmt.Println("Private key of bidder: ", bidder.PrivateKey)
+		created = append(created, bidder)
+	}
+	if len(created) == 0 {
+		return nil
+	}
+	return d.journal.Update(func(run *journal.Run) { run.Bidders = append(run.Bidders, created...) })
+}
+
+// bid funds and bids with the i-th bidder. Each step is journaled, so a resumed run continues
+// with the same account instead of stranding its funds.
+func (d *Driver) bid(ctx context.Context, i int, client *auction.Client, machine *auction.StateMachine) error {
+	return d.phase(ctx, "bid", func(ctx context.Context) error {
+		bidder := d.journal.Run().Bidders[i]
+		if bidder.BidPlaced {
+			return nil
+		}
+		snapshot, err := machine.Snapshot(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := snapshot.Check(auction.TransitionBid); err != nil {
+			return err
+		}
+		privKey := framework.NewPrivKeyFromHex(bidder.PrivateKey)
+		if !bidder.Funded {
+			if err := d.fundAccount(ctx, privKey.Address(), bidder); err != nil {
+				return err
+			}
+		}
+		return d.placeBid(ctx, privKey, bidder, client.Ref(privKey))
+	})
+}
+
+// bidderKey returns a fresh key, or the i-th key derived from the seed and the run index of the
+// run to make a run reproducible. The run index keeps the runs of a sweep from sharing accounts.
+func (d *Driver) bidderKey(i int) (*framework.PrivKey, error) {
+	run := d.journal.Run()
+	if run.Seed == 0 {
+		return framework.GeneratePrivKey(), nil
+	}
+	buf := make([]byte, 24)
+	binary.BigEndian.PutUint64(buf, uint64(run.Seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(run.SeedRun))
+	binary.BigEndian.PutUint64(buf[16:], uint64(i))
+	key, err := crypto.ToECDSA(crypto.Keccak256(buf))
+	if err != nil {
+		return nil, fmt.Errorf("deriving bidder key: %w", err)
+	}
+	return &framework.PrivKey{Priv: key}, nil
+}
+
+// RunID returns the ID of the current run, it is empty before Run or Resume was called.
+func (d *Driver) RunID() string {
+	if d.journal == nil {
+		return ""
+	}
+	return d.journal.Run().ID
+}
+
+// phase runs fn with the configured per-phase deadline
+func (d *Driver) phase(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	if d.config.PhaseTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.config.PhaseTimeout)
+		defer cancel()
+	}
+	return d.timed(ctx, name, fn)
+}
+
+// timed runs fn and records its wall-clock time as a span of the run
+func (d *Driver) timed(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	start := time.Now()
+	err := fn(ctx)
+	if spanErr := d.recorder.Span(name, start, time.Now(), err != nil); err == nil && spanErr != nil {
+		return spanErr
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// observeTx keeps the timing of a transaction until its receipt is recorded
+func (d *Driver) observeTx(timing framework.TxTiming) {
+	d.recorder.Timing(timing.Hash, metrics.Timing{
+		Execution:    timing.Execution,
+		Confirmation: timing.Confirmation,
+		PollRounds:   timing.PollRounds,
+	})
+}
+
+// sleep waits for duration or until ctx is done
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// deployContractWithConstructor deploys the artifact at _path, record adds the gas of the deployment
+// to the deploy phase of the metrics
+func (d *Driver) deployContractWithConstructor(ctx context.Context, _path string, record bool, params ...interface{}) (*framework.Contract, error) {
+	artifact, err := d.fr.ReadArtifact(_path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pack the constructor parameters
+	constructorParams, err := artifact.Abi.Pack("", params...)
+	if err != nil {
+		return nil, err
+	}
+	newClient := sdk.NewClient(d.SuaveClient.Client(), d.SuaveDevAccount.Priv, d.fr.KettleAddress)
+	sent := time.Now()
+	txnResult, err := sdk.DeployContract(append(artifact.Code, constructorParams...), newClient)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := framework.WaitForReceiptTimed(ctx, d.SuaveClient, txnResult.Hash(), time.Since(sent))
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == 0 {
+		return nil, fmt.Errorf("deploying %s: %w", _path, framework.ErrTxFailed)
+	}
+	if record {
+		if err := d.recorder.Receipt(metrics.PhaseDeploy, metrics.ChainSuave, receipt); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("deployed contract at %s", receipt.ContractAddress.Hex())
+	contract := sdk.GetContract(receipt.ContractAddress, artifact.Abi, newClient)
+
+	return framework.CreateContract(receipt.ContractAddress, newClient, d.fr.KettleAddress, artifact.Abi, contract), nil
+}
+
+// attach binds an auction contract deployed by an earlier run
+func (d *Driver) attach(auctionPath string, auctionAddress common.Address, oraclePath string) (*auction.Client, error) {
+	auctionArtifact, err := d.fr.ReadArtifact(auctionPath)
+	if err != nil {
+		return nil, err
+	}
+	oracleArtifact, err := d.fr.ReadArtifact(oraclePath)
+	if err != nil {
+		return nil, err
+	}
+	newClient := sdk.NewClient(d.SuaveClient.Client(), d.SuaveDevAccount.Priv, d.fr.KettleAddress)
+	contract := sdk.GetContract(auctionAddress, auctionArtifact.Abi, newClient)
+	return auction.NewClient(framework.CreateContract(auctionAddress, newClient, d.fr.KettleAddress, auctionArtifact.Abi, contract), oracleArtifact.Abi), nil
+}
+
+// deployOracle deploys the oracle called name, e.g. "Oracle", and registers the API keys
+func (d *Driver) deployOracle(ctx context.Context, name string) (*framework.Contract, error) {
+	_path, params := d.oracleDeployment(name)
+	oracle, err := d.deployContractWithConstructor(ctx, _path, false, params...)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := oracle.Call(ctx, "owner", nil)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("Oracle contract owner:", owner[0])
+	fmt.Println("Current sender:", d.SuaveDevAccount.Address())
+	receipt, err := oracle.SendConfidentialRequest(ctx, "registerApiKeyOffchain", []interface{}{"alchemy"}, []byte(d.config.AlchemyApiKey))
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		fmt.Println("ALCHEMY_API Key registered")
+	}
+	receipt, err = oracle.SendConfidentialRequest(ctx, "registerApiKeyOffchain", []interface{}{"etherscan"}, []byte(d.config.EtherscanApiKey))
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		fmt.Println("ETHERSCAN_API Key registered")
+	}
+	return oracle, nil
+}
+
+func (d *Driver) setUpAuction(ctx context.Context, client *auction.Client) (common.Address, error) {
+	setUp, err := client.SetUp(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	fmt.Println("NFTHoldingAddressEvent : ", setUp.NFTHoldingAddress)
+	if err := d.journal.RecordTx(journal.PhaseSetUp, journal.ChainSuave, setUp.Receipt.TxHash); err != nil {
+		return common.Address{}, err
+	}
+	return setUp.NFTHoldingAddress, d.recorder.Receipt(metrics.PhaseSetUp, metrics.ChainSuave, setUp.Receipt)
+}
+
+func (d *Driver) startAuction(ctx context.Context, client *auction.Client) error {
+	started, err := client.Start(ctx)
+	if err != nil {
+		fmt.Println("Starting the auction failed, will try again in 10 seconds")
+		if err := sleep(ctx, 10*time.Second); err != nil {
+			return err
+		}
+		started, err = client.Start(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if err := d.journal.RecordTx(journal.PhaseStarted, journal.ChainSuave, started.Receipt.TxHash); err != nil {
+		return err
+	}
+	fmt.Println("Contract Address:", started.ContractAddress)
+	fmt.Println("NFT Contract Address:", started.NFTContractAddress)
+	fmt.Println("NFT Token ID:", started.NFTTokenID)
+	fmt.Println("End Timestamp:", started.EndTimestamp)
+	fmt.Println("Minimal Bidding Amount:", started.MinimalBid)
+	return d.recorder.Receipt(metrics.PhaseStart, metrics.ChainSuave, started.Receipt)
+}
+
+func (d *Driver) getBiddingAddress(ctx context.Context, client *auction.Client) (common.Address, error) {
+	biddingAddress, err := client.RequestBiddingAddress(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	fmt.Println("Owner of Bidding address:", biddingAddress.Owner)
+	fmt.Println("Encrypted L1 bidding address:", hex.EncodeToString(biddingAddress.Encrypted))
+	fmt.Println("Decrypted L1 bidding address:", biddingAddress.Address.Hex())
+
+	return biddingAddress.Address, d.recorder.Receipt(metrics.PhaseBiddingAddress, metrics.ChainSuave, biddingAddress.Receipt)
+}
+
+func (d *Driver) placeBid(ctx context.Context, privKey *framework.PrivKey, bidder *journal.Bidder, client *auction.Client) error {
+	/* 	// this could places a certain amount, but we rather send all funds
+	   	amount := big.NewInt(15000000000000 + int64(rand.Intn(2000))) // (15.000 GWEI + ~2000)
+	   	// L1: create tx to send money
+	   	fmt.Println("Place bid with amount ", amount, " to adddress ", toAddress)
+	   	makeTransaction(privKey, amount, toAddress)
+	   	fmt.Println(privKey.Address(), " bid ", amount, " to ", toAddress) */
+	toAddress, err := d.getBiddingAddress(ctx, client)
+	if err != nil {
+		return err
+	}
+	if err := d.journal.Update(func(run *journal.Run) { bidder.BiddingAddress = toAddress }); err != nil {
+		return err
+	}
+	txHash, err := d.sendAllBalance(ctx, privKey, toAddress)
+	if err != nil {
+		return err
+	}
+	if txHash != (common.Hash{}) {
+		if err := d.journal.RecordTx(journal.PhaseBidding, journal.ChainL1, txHash); err != nil {
+			return err
+		}
+	}
+	return d.journal.Update(func(run *journal.Run) { bidder.BidPlaced = true })
+}
+
+func (d *Driver) endAuction(ctx context.Context, client *auction.Client) error {
+	ended, err := client.End(ctx)
+	if err != nil {
+		return err
+	}
+	receipt := ended.Receipt
+	if err := d.journal.RecordTx(journal.PhaseEnded, journal.ChainSuave, receipt.TxHash); err != nil {
+		return err
+	}
+
+	fmt.Println("Auction took gas: ", receipt.GasUsed)
+	fmt.Println("Effective gas price: ", receipt.EffectiveGasPrice)
+	fmt.Println("Cumulative gas used: ", receipt.CumulativeGasUsed)
+	fmt.Println("Revealed L1 addresses:", ended.RevealedAddresses)
+	printTransfers(ended.Transfers)
+	for _, txHash := range ended.TxHashes {
+		L1receipt, err := d.waitForL1Tx(ctx, txHash)
+		if err != nil {
+			return err
+		}
+		if err := d.recorder.Receipt(metrics.PhaseEnd, metrics.ChainL1, L1receipt); err != nil {
+			return err
+		}
+	}
+	return d.recorder.Receipt(metrics.PhaseEnd, metrics.ChainSuave, receipt)
+}
+
+// Used for winner, losers & Auction Owner. A failed claim is logged and reported as not claimed,
+// the other claims go on and the run stays resumable to retry it.
+func (d *Driver) claim(ctx context.Context, client *auction.Client) (bool, error) {
+	claimed, err := client.Claim(ctx, d.L1DevAccount.Address())
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		log.Println("Claim failed; continuing with the other claims")
+		log.Println(err)
+		return false, nil
+	}
+	if err := d.journal.RecordTx(journal.PhaseClaimed, journal.ChainSuave, claimed.Receipt.TxHash); err != nil {
+		return false, err
+	}
+	printTransfers(claimed.Transfers)
+	if err := d.variant.HandleClaimLogs(ctx, d, claimed); err != nil {
+		return false, err
+	}
+	return true, d.recorder.Receipt(metrics.PhaseClaim, metrics.ChainSuave, claimed.Receipt)
+}
+
+// fundAccount funds the bidder on L1 and on SUAVE and journals each chain on its own. Both
+// helpers only top up, so a crash after a transfer but before its journal entry is harmless.
+func (d *Driver) fundAccount(ctx context.Context, account common.Address, bidder *journal.Bidder) error {
+	if !bidder.FundedL1 {
+		fundBalance := big.NewInt(500000000000000) // fund 500.000 GWEI on L1
+		fmt.Println("Funding the L1 account with balance: ", fundBalance)
+		if err := d.fundL1Account(ctx, account, fundBalance); err != nil {
+			return err
+		}
+		if err := d.journal.Update(func(run *journal.Run) { bidder.FundedL1 = true }); err != nil {
+			return err
+		}
+	}
+	if !bidder.FundedSuave {
+		fundBalance := big.NewInt(200000000000000000) // 0,2 ETH on SUAVE
+		fmt.Println("Funding the Suave account with balance: ", fundBalance)
+		if err := d.fundSuaveAccount(ctx, account, fundBalance); err != nil {
+			return err
+		}
+		if err := d.journal.Update(func(run *journal.Run) { bidder.FundedSuave = true }); err != nil {
+			return err
+		}
+	}
+	return d.journal.Update(func(run *journal.Run) { bidder.Funded = true })
+}
+
+func (d *Driver) fundSuaveAccount(ctx context.Context, account common.Address, fundBalance *big.Int) error {
+	if err := d.fr.Suave.TopUpAccount(ctx, account, fundBalance); err != nil {
+		return err
+	}
+	bal, err := d.SuaveClient.BalanceAt(ctx, account, nil)
+	if err != nil {
+		return err
+	}
+	log.Printf("Balance of account on Suave chain: %s:\t%d", account, bal)
+	return nil
+}
+
+func printTransfers(transfers auction.Transfers) {
+	for _, errorMsg := range transfers.Errors {
+		fmt.Println("ErrorEvent : ", errorMsg)
+	}
+	for _, txHash := range transfers.TxHashes {
+		fmt.Println("TxEvent : ", txHash.Hex())
+	}
+	for _, signedTx := range transfers.SignedTxs {
+		fmt.Println("signedTx : ", signedTx)
+	}
+}
+
+func printOutcome(ctx context.Context, client *auction.Client) error {
+	outcome, err := client.Outcome(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Println("auctionWinnerL1 : ", outcome.WinnerL1)
+	fmt.Println("auctionWinnerSuave : ", outcome.WinnerSuave)
+	fmt.Println("winningBid : ", outcome.WinningBid)
+	return nil
+}
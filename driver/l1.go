@@ -0,0 +1,202 @@
+package driver
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"suave/sealedauction/framework"
+	"suave/sealedauction/journal"
+	"suave/sealedauction/l1"
+	"suave/sealedauction/metrics"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func (d *Driver) moveNft(ctx context.Context, toAddress common.Address, nftTokenID *big.Int, nftContractAddress common.Address, privKeySender *framework.PrivKey) error {
+	const erc721ABI = `[{"inputs":[{"internalType":"address","name":"from","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"payable","type":"function"}]`
+
+	contractABI, err := abi.JSON(strings.NewReader(erc721ABI))
+	if err != nil {
+		return err
+	}
+	data, err := contractABI.Pack("safeTransferFrom", privKeySender.Address(), toAddress, nftTokenID)
+	if err != nil {
+		return err
+	}
+
+	pending, err := d.l1Txs.Submit(ctx, l1.Request{From: privKeySender.Priv, To: nftContractAddress, Data: data, Gas: 200000})
+	if err != nil {
+		return err
+	}
+	log.Printf("Transaction sent! Hash: %s\n", pending.Hash().Hex())
+	if err := d.journal.RecordTx(journal.PhaseNFTMoved, journal.ChainL1, pending.Hash()); err != nil {
+		return err
+	}
+	result, err := pending.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Tx.Hash() != pending.Hash() {
+		if err := d.journal.RecordTx(journal.PhaseNFTMoved, journal.ChainL1, result.Tx.Hash()); err != nil {
+			return err
+		}
+	}
+	receipt := d.observeL1(ctx, result)
+	if receipt.Status == types.ReceiptStatusFailed {
+		return fmt.Errorf("Moving the NFT Failed")
+	}
+	return d.recorder.Receipt(metrics.PhaseMoveNFT, metrics.ChainL1, receipt)
+}
+
+// nftOwner calls ownerOf of the NFT contract on L1
+func (d *Driver) nftOwner(ctx context.Context, nftContract common.Address, tokenID *big.Int) (common.Address, error) {
+	const erc721ABI = `[{"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"ownerOf","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"}]`
+
+	contractABI, err := abi.JSON(strings.NewReader(erc721ABI))
+	if err != nil {
+		return common.Address{}, err
+	}
+	data, err := contractABI.Pack("ownerOf", tokenID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	output, err := d.L1client.CallContract(ctx, ethereum.CallMsg{To: &nftContract, Data: data}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	res, err := contractABI.Unpack("ownerOf", output)
+	if err != nil {
+		return common.Address{}, err
+	}
+	owner, ok := res[0].(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("ownerOf: unexpected output type %T", res[0])
+	}
+	return owner, nil
+}
+
+// fundL1Account tops the balance of to up to value plus the gas costs of a transfer. Nothing is
+// sent if the account already holds that much, e.g. when a resumed run funds it again.
+func (d *Driver) fundL1Account(ctx context.Context, to common.Address, value *big.Int) error {
+	funderAddr := d.L1DevAccount.Address()
+
+	balance, err := d.L1client.BalanceAt(ctx, funderAddr, nil)
+	if err != nil {
+		return err
+	}
+	gasPrice, err := d.L1client.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+	header, err := d.L1client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Consisting of value: %s, gasPrice: %s*21000 = %s, baseFee: %s", value, gasPrice, big.NewInt(0).Mul(gasPrice, big.NewInt(21000)), header.BaseFee)
+	log.Printf("funder %s with balance: %s", funderAddr.Hex(), balance.String())
+	value = new(big.Int).Set(value)
+	value.Add(value, header.BaseFee)         // add baseFee from last block
+	value.Add(value, big.NewInt(1000000000)) // plus one GWEI for priority
+	gasPrice.Mul(gasPrice, big.NewInt(21000))
+	value.Add(value, gasPrice) // add gascosts
+	current, err := d.L1client.BalanceAt(ctx, to, nil)
+	if err != nil {
+		return err
+	}
+	if current.Cmp(value) >= 0 {
+		log.Printf("account %s already holds %s of %s", to.Hex(), current, value)
+		return nil
+	}
+	topUp := new(big.Int).Sub(value, current)
+	log.Printf("funding account %s with %s .", to.Hex(), topUp.String())
+	// the funder sends to several bidders at once, the manager assigns the nonces
+	if _, err := d.sendL1(ctx, l1.Request{From: d.L1DevAccount.Priv, To: to, Value: topUp, Gas: 21000}); err != nil {
+		return err
+	}
+	// check Balance
+	balance, err = d.L1client.BalanceAt(ctx, to, nil)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(value) < 0 {
+		return fmt.Errorf("failed to fund account %s: balance %s is below %s", to.Hex(), balance, value)
+	}
+	log.Printf("Balance of account on L1 chain: %s:\t%d", to, balance)
+	return nil
+}
+
+// sendL1 sends a transaction through the L1 transaction manager and returns the final receipt
+func (d *Driver) sendL1(ctx context.Context, req l1.Request) (*types.Receipt, error) {
+	result, err := d.l1Txs.Send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return d.observeL1(ctx, result), nil
+}
+
+// observeL1 reports the timing of an L1 transaction and returns its receipt
+func (d *Driver) observeL1(ctx context.Context, result *l1.Result) *types.Receipt {
+	if result.Replacements > 0 {
+		fmt.Println("Transaction included after", result.Replacements, "fee bumps:", result.Tx.Hash().Hex())
+	} else {
+		fmt.Println("Transaction included!")
+	}
+	framework.ObserveTx(ctx, framework.TxTiming{
+		Hash:         result.Receipt.TxHash,
+		Execution:    result.Execution,
+		Confirmation: result.Confirmation,
+		PollRounds:   result.PollRounds,
+	})
+	return result.Receipt
+}
+
+// waits for an L1 transaction issued by the oracle and returns its receipt
+func (d *Driver) waitForL1Tx(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	result, err := d.l1Txs.Wait(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	return d.observeL1(ctx, result), nil
+}
+
+// broadcasts a RLP encoded transaction signed by the oracle and returns its receipt
+func (d *Driver) sendSignedTx(ctx context.Context, signedTxHex string) (*types.Receipt, error) {
+	txBytes, err := hex.DecodeString(strings.TrimPrefix(signedTxHex, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
+		return nil, err
+	}
+	fmt.Println("Sending signed transaction:", tx.Hash().Hex())
+	result, err := d.l1Txs.Broadcast(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return d.observeL1(ctx, result), nil
+}
+
+// sendAllBalance transfers the balance of privKey minus the gas costs to to and returns the hash of
+// the transfer. The hash is zero if the balance does not cover the gas.
+func (d *Driver) sendAllBalance(ctx context.Context, privKey *framework.PrivKey, to common.Address) (common.Hash, error) {
+	receipt, err := d.sendL1(ctx, l1.Request{From: privKey.Priv, To: to, Gas: 21000, Sweep: true})
+	if errors.Is(err, l1.ErrInsufficientBalance) {
+		fmt.Printf("Skipping the bid of %s: %v\n", privKey.Address().Hex(), err)
+		return common.Hash{}, nil
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+	fmt.Printf("Sent all balance minus gas from %s to %s\n", privKey.Address().Hex(), to.Hex())
+	return receipt.TxHash, nil
+}
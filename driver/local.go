@@ -0,0 +1,67 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"suave/sealedauction/framework"
+	"suave/sealedauction/l1"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// deployTestNFT deploys a TestNFT on the L1 devnet and mints a token to the auctioneer
+func (d *Driver) deployTestNFT(ctx context.Context) (common.Address, *big.Int, error) {
+	fmt.Println("Deploying a TestNFT on the local L1")
+	nft, err := d.fr.L1.DeployContract(ctx, "TestNFT.sol/TestNFT.json")
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("deploying the TestNFT: %w", err)
+	}
+	nftAddress := nft.Raw().Address()
+	data, err := nft.Abi.Pack("mint", d.L1DevAccount.Address())
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	receipt, err := d.sendL1(ctx, l1.Request{From: d.L1DevAccount.Priv, To: nftAddress, Data: data})
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("minting the TestNFT: %w", err)
+	}
+	transfer, ok := nft.Abi.Events["Transfer"]
+	if !ok {
+		return common.Address{}, nil, fmt.Errorf("the TestNFT ABI has no Transfer event")
+	}
+	// Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+	for _, entry := range receipt.Logs {
+		if entry.Address == nftAddress && len(entry.Topics) == 4 && entry.Topics[0] == transfer.ID {
+			tokenID := new(big.Int).SetBytes(entry.Topics[3].Bytes())
+			fmt.Println("Minted TestNFT", nftAddress.Hex(), "token", tokenID, "to", d.L1DevAccount.Address().Hex())
+			return nftAddress, tokenID, nil
+		}
+	}
+	return common.Address{}, nil, fmt.Errorf("minting the TestNFT: %w", framework.ErrTxFailed)
+}
+
+// the endpoints the Oracle and OracleProposer are compiled with
+const (
+	oracleAlchemyURL   = "https://eth-sepolia.g.alchemy.com/v2/"
+	oracleEtherscanURL = "https://api-sepolia.etherscan.io/api"
+)
+
+// oracleDeployment returns the artifact and constructor arguments of the oracle called name. Other
+// endpoints than the compiled-in ones need the TestOracle variant from test/, which fixes them at
+// deployment and is only meant for local runs and recordings.
+func (d *Driver) oracleDeployment(name string) (string, []interface{}) {
+	alchemyURL, etherscanURL := d.config.OracleAlchemyURL, d.config.OracleEtherscanURL
+	if (alchemyURL == "" || alchemyURL == oracleAlchemyURL) && (etherscanURL == "" || etherscanURL == oracleEtherscanURL) {
+		return name + ".sol/" + name + ".json", []interface{}{d.L1chainID}
+	}
+	if alchemyURL == "" {
+		alchemyURL = oracleAlchemyURL
+	}
+	if etherscanURL == "" {
+		etherscanURL = oracleEtherscanURL
+	}
+	fmt.Println("Deploying Test"+name, "querying", alchemyURL, "and", etherscanURL)
+	return "Test" + name + ".sol/Test" + name + ".json", []interface{}{d.L1chainID, alchemyURL, etherscanURL}
+}
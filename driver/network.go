@@ -0,0 +1,91 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Network is a named L1 profile, selected with L1_NETWORK in the .env file.
+type Network struct {
+	Name string
+	// RPC is the default endpoint, an Infura URL expects the API key to be appended
+	RPC string
+	// ChainID is the chain the RPC must serve, 0 accepts any chain, e.g. a local devnet
+	ChainID uint64
+	// AlchemyURL and EtherscanURL are the endpoints the Oracle queries, the registered API keys
+	// are appended to them
+	AlchemyURL   string
+	EtherscanURL string
+	// Local runs everything against the L1 devnet of the framework and deploys a TestNFT to
+	// auction if no NFT is configured
+	Local bool
+	// whether the API key of INFURA_API_KEY is appended to RPC
+	needsAPIKey bool
+}
+
+var networks = map[string]Network{
+	"local": {
+		Name: "local",
+		RPC:  "http://localhost:8555",
+		// the Alchemy and Etherscan stand-in of cmd/standin
+		AlchemyURL:   "http://localhost:8556/",
+		EtherscanURL: "http://localhost:8556/api",
+		Local:        true,
+	},
+	"sepolia": {
+		Name:         "sepolia",
+		RPC:          "https://sepolia.infura.io/v3/",
+		ChainID:      SEPOLIA_CHAIN_ID,
+		AlchemyURL:   "https://eth-sepolia.g.alchemy.com/v2/",
+		EtherscanURL: "https://api-sepolia.etherscan.io/api",
+		needsAPIKey:  true,
+	},
+	"holesky": {
+		Name:         "holesky",
+		RPC:          "https://holesky.infura.io/v3/",
+		ChainID:      HOLESKY_CHAIN_ID,
+		AlchemyURL:   "https://eth-holesky.g.alchemy.com/v2/",
+		EtherscanURL: "https://api-holesky.etherscan.io/api",
+		needsAPIKey:  true,
+	},
+}
+
+// NetworkByName returns the L1 profile called name.
+func NetworkByName(name string) (Network, error) {
+	network, ok := networks[strings.ToLower(name)]
+	if !ok {
+		return Network{}, fmt.Errorf("unknown L1 network %q, expected one of: %s", name, strings.Join(NetworkNames(), ", "))
+	}
+	return network, nil
+}
+
+// NetworkNames lists the names of the L1 profiles.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// detectChainID asks the L1 RPC for its chain ID via eth_chainId and checks it against the
+// configured network
+func detectChainID(client *ethclient.Client, network Network) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying the L1 chain ID: %w", err)
+	}
+	if network.ChainID != 0 && chainID.Uint64() != network.ChainID {
+		return nil, fmt.Errorf("L1 RPC serves chain %s, but network %s is chain %d", chainID, network.Name, network.ChainID)
+	}
+	return chainID, nil
+}
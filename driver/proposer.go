@@ -0,0 +1,34 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"suave/sealedauction/auction"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RunProposer runs an auction.Proposer for the SealedAuctionProposer at auctionAddress with the
+// SUAVE dev account until its refute time is over. Progress and every refuteWinner request are
+// printed.
+func (d *Driver) RunProposer(ctx context.Context, auctionAddress common.Address, interval time.Duration) (*auction.Verification, error) {
+	client, err := d.variant.Attach(d, auctionAddress)
+	if err != nil {
+		return nil, err
+	}
+	p := auction.NewProposer(client, d.L1client)
+	p.PollInterval = interval
+	p.Logf = func(format string, args ...interface{}) {
+		fmt.Printf(time.Now().Format(time.TimeOnly)+" "+format+"\n", args...)
+	}
+	p.OnRefute = func(res *auction.RefuteResult) error {
+		fmt.Println("refuteWinner", res.Receipt.TxHash.Hex())
+		for _, msg := range res.Errors {
+			fmt.Println("  ", msg)
+		}
+		return printOutcome(ctx, client)
+	}
+	return p.Run(ctx)
+}
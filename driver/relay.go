@@ -0,0 +1,38 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"suave/sealedauction/bindings"
+	"suave/sealedauction/relay"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RunRelay relays the EncodedTx transactions of oracles from SUAVE block fromBlock on until ctx is
+// done, see relay.Relay. The transactions of an oracle in auctions are checked against its auction,
+// recipients restricts the accepted recipients if it is not empty. Every oracle needs one of both.
+func (d *Driver) RunRelay(ctx context.Context, oracles []common.Address, auctions map[common.Address]*relay.Auction, fromBlock uint64, recipients []common.Address, interval time.Duration) error {
+	oracleAbi, err := bindings.ParseOracleProposerABI()
+	if err != nil {
+		return err
+	}
+	r, err := relay.New(d.SuaveClient, d.L1client, d.l1Txs, d.L1chainID, oracleAbi, oracles)
+	if err != nil {
+		return err
+	}
+	r.Auctions = auctions
+	if len(recipients) > 0 {
+		r.Recipients = map[common.Address]bool{}
+		for _, recipient := range recipients {
+			r.Recipients[recipient] = true
+		}
+	}
+	r.PollInterval = interval
+	r.Logf = func(format string, args ...interface{}) {
+		fmt.Printf(time.Now().Format(time.TimeOnly)+" "+format+"\n", args...)
+	}
+	return r.Run(ctx, fromBlock)
+}
@@ -0,0 +1,207 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"suave/sealedauction/auction"
+	"suave/sealedauction/bindings"
+	"suave/sealedauction/journal"
+	"suave/sealedauction/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Variant captures what differs between the SealedAuction and the SealedAuctionProposer flow.
+type Variant interface {
+	// Name selects the variant on the command line
+	Name() string
+	// Title is used in the header of each run in measurements.txt
+	Title() string
+	// Deploy deploys and configures the oracle and the auction contract on SUAVE
+	Deploy(ctx context.Context, d *Driver, params DeployParams) (*auction.Client, error)
+	// Attach creates a client for contracts deployed by an earlier run
+	Attach(d *Driver, auctionAddress common.Address) (*auction.Client, error)
+	// Finalize runs after the auction ended and before anyone claims
+	Finalize(ctx context.Context, d *Driver, client *auction.Client, num_bidder int) error
+	// HandleClaimLogs settles the L1 side of a successful claim
+	HandleClaimLogs(ctx context.Context, d *Driver, claimed *auction.ClaimResult) error
+}
+
+type DeployParams struct {
+	NFTContractAddress common.Address
+	NFTTokenID         *big.Int
+	AuctionEndTime     *big.Int
+	MinimalBid         *big.Int
+}
+
+var variants = map[string]Variant{}
+
+func register(v Variant) {
+	variants[v.Name()] = v
+}
+
+func init() {
+	register(SealedAuction{})
+	register(SealedAuctionProposer{RefuteTime: big.NewInt(30)})
+}
+
+// VariantByName looks up one of the registered variants.
+func VariantByName(name string) (Variant, error) {
+	v, ok := variants[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown auction variant %q, expected one of %v", name, VariantNames())
+	}
+	return v, nil
+}
+
+// VariantByTitle looks up a variant by the title used in measurements.txt.
+func VariantByTitle(title string) (Variant, error) {
+	for _, v := range variants {
+		if v.Title() == title {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown auction title %q", title)
+}
+
+// VariantNameByTitle maps a title used in measurements.txt to the variant name. Unknown titles
+// are returned unchanged.
+func VariantNameByTitle(title string) string {
+	v, err := VariantByTitle(title)
+	if err != nil {
+		return title
+	}
+	return v.Name()
+}
+
+func VariantNames() []string {
+	names := make([]string, 0, len(variants))
+	for name := range variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SealedAuction determines the winner inside endAuction by letting the oracle check every bidding address.
+type SealedAuction struct{}
+
+func (SealedAuction) Name() string  { return "base" }
+func (SealedAuction) Title() string { return "auction" }
+
+func (SealedAuction) Deploy(ctx context.Context, d *Driver, params DeployParams) (*auction.Client, error) {
+	fmt.Println("0. Preparation: Deploy oracle on TOLIMAN SUAVE CHAIN")
+	oracle, err := d.deployOracle(ctx, "Oracle")
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println("1. Deploy Sealed Auction contract on TOLIMAN SUAVE CHAIN")
+	contract, err := d.deployContractWithConstructor(ctx, "SealedAuction.sol/SealedAuction.json", true, params.NFTContractAddress, params.NFTTokenID, params.AuctionEndTime, params.MinimalBid, oracle.Raw().Address())
+	if err != nil {
+		return nil, err
+	}
+	return auction.NewClient(contract, oracle.Abi), nil
+}
+
+func (SealedAuction) Attach(d *Driver, auctionAddress common.Address) (*auction.Client, error) {
+	return d.attach("SealedAuction.sol/SealedAuction.json", auctionAddress, "Oracle.sol/Oracle.json")
+}
+
+func (SealedAuction) Finalize(ctx context.Context, d *Driver, client *auction.Client, num_bidder int) error {
+	return nil
+}
+
+func (SealedAuction) HandleClaimLogs(ctx context.Context, d *Driver, claimed *auction.ClaimResult) error {
+	for _, txHash := range claimed.TxHashes {
+		L1receipt, err := d.waitForL1Tx(ctx, txHash)
+		if err != nil {
+			return err
+		}
+		if err := d.recorder.Receipt(metrics.PhaseClaim, metrics.ChainL1, L1receipt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SealedAuctionProposer only reveals the bidding addresses in endAuction. Afterwards anyone can
+// propose a winner via refuteWinner until the refute time is over. The oracle emits signed
+// transactions (EncodedTx) instead of sending them.
+type SealedAuctionProposer struct {
+	// RefuteTime in seconds after the auction end time
+	RefuteTime *big.Int
+}
+
+func (SealedAuctionProposer) Name() string  { return "proposer" }
+func (SealedAuctionProposer) Title() string { return "rollup auction" }
+
+func (p SealedAuctionProposer) Deploy(ctx context.Context, d *Driver, params DeployParams) (*auction.Client, error) {
+	fmt.Println("0. Preparation: Deploy oracle on TOLIMAN SUAVE CHAIN")
+	oracle, err := d.deployOracle(ctx, "OracleProposer")
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println("1. Deploy Sealed Auction contract on TOLIMAN SUAVE CHAIN")
+	contract, err := d.deployContractWithConstructor(ctx, "SealedAuctionProposer.sol/SealedAuctionProposer.json", true, params.NFTContractAddress, params.NFTTokenID, params.AuctionEndTime, params.MinimalBid, oracle.Raw().Address(), p.RefuteTime)
+	if err != nil {
+		return nil, err
+	}
+	return auction.NewClient(contract, oracle.Abi), nil
+}
+
+func (SealedAuctionProposer) Attach(d *Driver, auctionAddress common.Address) (*auction.Client, error) {
+	return d.attach("SealedAuctionProposer.sol/SealedAuctionProposer.json", auctionAddress, "OracleProposer.sol/OracleProposer.json")
+}
+
+func (SealedAuctionProposer) Finalize(ctx context.Context, d *Driver, client *auction.Client, num_bidder int) error {
+	contract := bindings.NewSealedAuctionProposer(client.Contract())
+	for i := range num_bidder {
+		bidder, err := contract.RevealedL1Addresses(ctx, big.NewInt(int64(i)))
+		if err != nil {
+			return err
+		}
+		fmt.Println("bidder ", i, " ", bidder)
+		receipt, err := contract.RefuteWinner(ctx, bidder, nil)
+		if err != nil && ctx.Err() == nil {
+			fmt.Println("Trying again")
+			receipt, err = contract.RefuteWinner(ctx, bidder, nil)
+		}
+		if err != nil {
+			return err
+		}
+		if err := d.journal.RecordTx(journal.PhaseFinalized, journal.ChainSuave, receipt.TxHash); err != nil {
+			return err
+		}
+		if err := d.recorder.Receipt(metrics.PhaseRefute, metrics.ChainSuave, receipt); err != nil {
+			return err
+		}
+		if err := printOutcome(ctx, client); err != nil {
+			return err
+		}
+	}
+
+	// Funding the nftHoldingAddress so the NFT can be returned
+	nftHoldingAddress, err := client.NFTHoldingAddress(ctx)
+	if err != nil {
+		return err
+	}
+	return d.fundL1Account(ctx, nftHoldingAddress, big.NewInt(1000000000000000))
+}
+
+func (SealedAuctionProposer) HandleClaimLogs(ctx context.Context, d *Driver, claimed *auction.ClaimResult) error {
+	for _, signedTx := range claimed.SignedTxs {
+		L1receipt, err := d.sendSignedTx(ctx, signedTx)
+		if err != nil {
+			return err
+		}
+		if err := d.recorder.Receipt(metrics.PhaseClaim, metrics.ChainL1, L1receipt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+
+	"suave/sealedauction/auction"
+	"suave/sealedauction/proof"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// VerifyWinner recomputes the winner of the ended auction at auctionAddress from the L1 balances
+// of its bidding addresses, see auction.Client.Verify. The driver must have been created with the
+// variant of the auction.
+func (d *Driver) VerifyWinner(ctx context.Context, auctionAddress common.Address) (*auction.Verification, error) {
+	client, err := d.variant.Attach(d, auctionAddress)
+	if err != nil {
+		return nil, err
+	}
+	return client.Verify(ctx, d.L1client)
+}
+
+// ExportOutcomeProof verifies the winner of the ended auction at auctionAddress like VerifyWinner
+// and bundles the verification with the account proofs of the bidding addresses, see proof.Export.
+func (d *Driver) ExportOutcomeProof(ctx context.Context, auctionAddress common.Address) (*proof.Bundle, error) {
+	v, err := d.VerifyWinner(ctx, auctionAddress)
+	if err != nil {
+		return nil, err
+	}
+	return proof.Export(ctx, d.L1client, d.L1chainID, auctionAddress, v)
+}
+
+// verifyWinner reports whether the registered winner matches the L1 balances. A mismatch or a
+// failed lookup is printed but does not abort the run, the valuables can be claimed regardless.
+func (d *Driver) verifyWinner(ctx context.Context, client *auction.Client) {
+	v, err := client.Verify(ctx, d.L1client)
+	if err != nil {
+		fmt.Println("Verifying the winner failed:", err)
+		return
+	}
+	PrintVerification(v)
+}
+
+// PrintVerification prints the balances at the end of the auction and the comparison of the
+// registered and the expected outcome.
+func PrintVerification(v *auction.Verification) {
+	fmt.Println("Balances at L1 block", v.Block, "(auction ended at", v.EndTime, ")")
+	for _, b := range v.Balances {
+		fmt.Println("  ", b.Address.Hex(), b.Balance)
+	}
+	if v.BelowMinimalBid {
+		fmt.Println("The highest bid is below the minimal bid of", v.MinimalBid, "so the auctioneer wins")
+	}
+	status := func(ok bool) string {
+		if ok {
+			return "ok"
+		}
+		return "MISMATCH"
+	}
+	fmt.Println("Winner: registered", v.Registered.WinnerL1.Hex(), "expected", v.Expected.WinnerL1.Hex(), status(v.WinnerMatches))
+	fmt.Println("Winning bid: registered", v.Registered.WinningBid, "expected", v.Expected.WinningBid, status(v.BidMatches))
+}
@@ -0,0 +1,70 @@
+package framework
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+type Artifact struct {
+	Abi *abi.ABI
+
+	// Code is the code to deploy the contract
+	Code []byte
+}
+
+// ReadArtifact reads an artifact from $ARTIFACT_DIR or, if it is not set, from out/ of the source checkout.
+// Use Framework.ReadArtifact to honor the artifacts configured on the framework.
+func ReadArtifact(path string) (*Artifact, error) {
+	dir := os.Getenv("ARTIFACT_DIR")
+	if dir == "" {
+		dir = sourceArtifactDir()
+	}
+	return ReadArtifactFS(os.DirFS(dir), path)
+}
+
+// ReadArtifactFS reads a forge artifact such as "Oracle.sol/Oracle.json" from fsys.
+func ReadArtifactFS(fsys fs.FS, path string) (*Artifact, error) {
+	data, err := fs.ReadFile(fsys, filepath.ToSlash(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading artifact: %w", err)
+	}
+
+	var artifact struct {
+		Abi      *abi.ABI `json:"abi"`
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("decoding artifact %s: %w", path, err)
+	}
+
+	code, err := hex.DecodeString(strings.TrimPrefix(artifact.Bytecode.Object, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decoding bytecode of %s: %w", path, err)
+	}
+
+	art := &Artifact{
+		Abi:  artifact.Abi,
+		Code: code,
+	}
+	return art, nil
+}
+
+// sourceArtifactDir is out/ of the source checkout this package was compiled from. It only
+// exists on the machine that built the binary.
+func sourceArtifactDir() string {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		return "out"
+	}
+	return filepath.Join(filepath.Dir(filename), "../out")
+}
@@ -0,0 +1,94 @@
+package framework
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/suave/artifacts"
+)
+
+// PeekerRevertedError is returned when the confidential execution of a request reverted in the kettle.
+type PeekerRevertedError struct {
+	// Peeker is the contract whose confidential execution reverted
+	Peeker common.Address
+	// Reason is the decoded revert message, e.g. the message of a failing require
+	Reason string
+}
+
+func (e *PeekerRevertedError) Error() string {
+	return fmt.Sprintf("peeker 0x%x reverted: %s", e.Peeker, e.Reason)
+}
+
+// RevertError is a standard Solidity Error(string) revert.
+type RevertError struct {
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+// ErrTxFailed is returned when a transaction was included but its receipt has a failed status.
+var ErrTxFailed = errors.New("status not correct")
+
+var executionRevertedPrefix = "execution reverted: 0x"
+
+// decodeRevertError turns the revert data contained in err into a *PeekerRevertedError or a *RevertError.
+// Errors without decodable revert data are returned unchanged.
+func decodeRevertError(err error) error {
+	data, ok := revertData(err)
+	if !ok {
+		return err
+	}
+	peekerReverted := artifacts.SuaveAbi.Errors["PeekerReverted"]
+	if len(data) >= 4 && bytes.Equal(data[:4], peekerReverted.ID[:4]) {
+		unpacked, uerr := peekerReverted.Inputs.Unpack(data[4:])
+		if uerr != nil || len(unpacked) != 2 {
+			return err
+		}
+		addr, _ := unpacked[0].(common.Address)
+		reason, _ := unpacked[1].([]byte)
+		return &PeekerRevertedError{Peeker: addr, Reason: revertReason(reason)}
+	}
+	if reason, uerr := abi.UnpackRevert(data); uerr == nil {
+		return &RevertError{Reason: reason}
+	}
+	return err
+}
+
+// revertData extracts the raw revert data either from the JSON-RPC error data or from the error message.
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if hexData, ok := dataErr.ErrorData().(string); ok {
+			if data, derr := hexutil.Decode(hexData); derr == nil && len(data) > 0 {
+				return data, true
+			}
+		}
+	}
+	errMsg := err.Error()
+	if idx := strings.Index(errMsg, executionRevertedPrefix); idx >= 0 {
+		hexData := errMsg[idx+len(executionRevertedPrefix):]
+		if end := strings.IndexAny(hexData, " \n"); end >= 0 {
+			hexData = hexData[:end]
+		}
+		if data, derr := hexutil.Decode("0x" + hexData); derr == nil && len(data) > 0 {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// revertReason decodes the reason of a nested revert, falling back to the raw bytes.
+func revertReason(data []byte) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	return string(data)
+}
@@ -0,0 +1,90 @@
+package framework
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/suave/artifacts"
+)
+
+// Error("not started")
+const notStarted = "0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"000000000000000000000000000000000000000000000000000000000000000b" +
+	"6e6f742073746172746564000000000000000000000000000000000000000000"
+
+// dataError is a JSON-RPC error with revert data, like the errors of rpc.Client
+type dataError struct {
+	msg  string
+	data interface{}
+}
+
+func (e *dataError) Error() string          { return e.msg }
+func (e *dataError) ErrorData() interface{} { return e.data }
+
+func TestDecodeRevertError(t *testing.T) {
+	peeker := common.Address{0x42}
+	peekerReverted := artifacts.SuaveAbi.Errors["PeekerReverted"]
+	args, err := peekerReverted.Inputs.Pack(peeker, hexutil.MustDecode(notStarted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	peekerData := hexutil.Encode(append(append([]byte{}, peekerReverted.ID[:4]...), args...))
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		// want is the expected error, nil if err must be returned unchanged
+		want error
+	}{
+		{
+			name: "revert data of the RPC error",
+			err:  &dataError{msg: "execution reverted", data: notStarted},
+			want: &RevertError{Reason: "not started"},
+		},
+		{
+			name: "revert data in the message",
+			err:  errors.New("execution reverted: " + notStarted[2:] + " (request failed)"),
+			want: &RevertError{Reason: "not started"},
+		},
+		{
+			name: "PeekerReverted with a nested Error(string)",
+			err:  &dataError{msg: "execution reverted", data: peekerData},
+			want: &PeekerRevertedError{Peeker: peeker, Reason: "not started"},
+		},
+		{
+			name: "no revert data",
+			err:  errors.New("connection refused"),
+		},
+		{
+			name: "revert data that is not hex",
+			err:  &dataError{msg: "execution reverted", data: "not hex"},
+		},
+		{
+			name: "unknown selector",
+			err:  &dataError{msg: "execution reverted", data: "0xdeadbeef"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decodeRevertError(tc.err)
+			switch want := tc.want.(type) {
+			case nil:
+				if got != tc.err {
+					t.Fatalf("got %v, expected the error unchanged", got)
+				}
+			case *RevertError:
+				var revertErr *RevertError
+				if !errors.As(got, &revertErr) || *revertErr != *want {
+					t.Fatalf("got %#v, expected %#v", got, want)
+				}
+			case *PeekerRevertedError:
+				var peekerErr *PeekerRevertedError
+				if !errors.As(got, &peekerErr) || *peekerErr != *want {
+					t.Fatalf("got %#v, expected %#v", got, want)
+				}
+			}
+		})
+	}
+}
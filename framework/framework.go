@@ -5,15 +5,14 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding"
-	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"math/big"
 	"os"
-	"path/filepath"
-	"runtime"
-	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -22,52 +21,10 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
-	"github.com/ethereum/go-ethereum/suave/artifacts"
 	"github.com/ethereum/go-ethereum/suave/sdk"
 	envconfig "github.com/sethvargo/go-envconfig"
 )
 
-type Artifact struct {
-	Abi *abi.ABI
-
-	// Code is the code to deploy the contract
-	Code []byte
-}
-
-func ReadArtifact(path string) (*Artifact, error) {
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return nil, fmt.Errorf("unable to get the current filename")
-	}
-	dirname := filepath.Dir(filename)
-
-	data, err := os.ReadFile(filepath.Join(dirname, "../out", path))
-	if err != nil {
-		return nil, err
-	}
-
-	var artifact struct {
-		Abi      *abi.ABI `json:"abi"`
-		Bytecode struct {
-			Object string `json:"object"`
-		} `json:"bytecode"`
-	}
-	if err := json.Unmarshal(data, &artifact); err != nil {
-		return nil, err
-	}
-
-	code, err := hex.DecodeString(artifact.Bytecode.Object[2:])
-	if err != nil {
-		return nil, err
-	}
-
-	art := &Artifact{
-		Abi:  artifact.Abi,
-		Code: code,
-	}
-	return art, nil
-}
-
 var _ encoding.TextUnmarshaler = &PrivKey{}
 
 type PrivKey struct {
@@ -117,61 +74,59 @@ type Contract struct {
 	Abi  *abi.ABI
 }
 
-func (c *Contract) Call(methodName string, args []interface{}) []interface{} {
+// Call executes a view method of the contract and returns its unpacked outputs.
+func (c *Contract) Call(ctx context.Context, methodName string, args []interface{}) ([]interface{}, error) {
 	input, err := c.Abi.Pack(methodName, args...)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("packing %s: %w", methodName, err)
 	}
 
 	callMsg := ethereum.CallMsg{
 		To:   &c.addr,
 		Data: input,
 	}
-	output, err := c.clt.RPC().CallContract(context.Background(), callMsg, nil)
+	output, err := c.clt.RPC().CallContract(ctx, callMsg, nil)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("calling %s: %w", methodName, decodeRevertError(err))
 	}
 
 	results, err := c.Abi.Methods[methodName].Outputs.Unpack(output)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("unpacking %s: %w", methodName, err)
 	}
-	return results
+	return results, nil
 }
 
 func (c *Contract) Raw() *sdk.Contract {
 	return c.contract
 }
 
-var executionRevertedPrefix = "execution reverted: 0x"
+// RPC returns the client of the chain the contract is deployed on.
+func (c *Contract) RPC() *ethclient.Client {
+	return c.clt.RPC()
+}
 
-// SendConfidentialRequest sends the confidential request to the kettle
-func (c *Contract) SendConfidentialRequest(method string, args []interface{}, confidentialBytes []byte) (*types.Receipt, error) {
+// SendConfidentialRequest sends the confidential request to the kettle and waits until it is included.
+// Reverts are returned as *PeekerRevertedError or *RevertError.
+func (c *Contract) SendConfidentialRequest(ctx context.Context, method string, args []interface{}, confidentialBytes []byte) (*types.Receipt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sent := time.Now()
 	txnResult, err := c.contract.SendTransaction(method, args, confidentialBytes)
 	if err != nil {
-		// decode the PeekerReverted error
-		errMsg := err.Error()
-		if strings.HasPrefix(errMsg, executionRevertedPrefix) {
-			errMsg = errMsg[len(executionRevertedPrefix):]
-			errMsgBytes, _ := hex.DecodeString(errMsg)
-
-			unpacked, _ := artifacts.SuaveAbi.Errors["PeekerReverted"].Inputs.Unpack(errMsgBytes[4:])
-
-			addr, _ := unpacked[0].(common.Address)
-			eventErr, _ := unpacked[1].([]byte)
-			panic(fmt.Sprintf("peeker 0x%x reverted: %s", addr, eventErr))
-		}
-		return nil, err
+		return nil, decodeRevertError(err)
 	}
+	execution := time.Since(sent)
 
 	log.Printf("transaction hash: %s", txnResult.Hash().Hex())
 
-	receipt, err := txnResult.Wait()
+	receipt, err := WaitForReceiptTimed(ctx, c.clt.RPC(), txnResult.Hash(), execution)
 	if err != nil {
 		return nil, err
 	}
 	if receipt.Status == 0 {
-		return nil, fmt.Errorf("status not correct")
+		return nil, ErrTxFailed
 	}
 	return receipt, nil
 }
@@ -180,6 +135,9 @@ type Framework struct {
 	config        *Config
 	KettleAddress common.Address
 
+	// Artifacts holds the forge build output the contracts are deployed from
+	Artifacts fs.FS
+
 	Suave *Chain
 	L1    *Chain
 }
@@ -199,6 +157,12 @@ type Config struct {
 
 	// Whether to enable L1 or not
 	L1Enabled bool
+
+	// Directory of the forge artifacts. Defaults to the embedded artifacts if there are any
+	// and to out/ of the source checkout otherwise.
+	ArtifactDir string `env:"ARTIFACT_DIR"`
+
+	artifacts fs.FS
 }
 
 type ConfigOption func(c *Config)
@@ -209,10 +173,37 @@ func WithL1() ConfigOption {
 	}
 }
 
-func New(opts ...ConfigOption) *Framework {
+// WithArtifactDir loads the artifacts from dir instead of out/ of the source checkout.
+func WithArtifactDir(dir string) ConfigOption {
+	return func(c *Config) {
+		c.ArtifactDir = dir
+	}
+}
+
+// WithArtifacts loads the artifacts from fsys, e.g. an embed.FS baked into the binary.
+// An explicitly configured ArtifactDir still takes precedence.
+func WithArtifacts(fsys fs.FS) ConfigOption {
+	return func(c *Config) {
+		c.artifacts = fsys
+	}
+}
+
+func (c *Config) artifactFS() fs.FS {
+	if c.ArtifactDir != "" {
+		return os.DirFS(c.ArtifactDir)
+	}
+	if c.artifacts != nil {
+		return c.artifacts
+	}
+	return os.DirFS(sourceArtifactDir())
+}
+
+// New connects to the kettle and, with WithL1, to the L1 devnet. It returns an error instead of
+// exiting if the configuration is invalid or a node is unreachable.
+func New(opts ...ConfigOption) (*Framework, error) {
 	var config Config
 	if err := envconfig.Process(context.Background(), &config); err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("framework config: %w", err)
 	}
 	for _, opt := range opts {
 		opt(&config)
@@ -220,65 +211,100 @@ func New(opts ...ConfigOption) *Framework {
 
 	kettleRPC, err := rpc.Dial(config.KettleRPC)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("dialing the kettle: %w", err)
 	}
 
 	var accounts []common.Address
 	if err := kettleRPC.Call(&accounts, "eth_kettleAddress"); err != nil {
-		panic(fmt.Sprintf("failed to get kettle address: %v", err))
+		kettleRPC.Close()
+		return nil, fmt.Errorf("failed to get kettle address: %w", err)
+	}
+	if len(accounts) == 0 {
+		kettleRPC.Close()
+		return nil, errors.New("the kettle reported no address")
 	}
 
 	suaveClt := sdk.NewClient(kettleRPC, config.FundedAccount.Priv, accounts[0])
 	suaveClt.WithEIP712()
 
+	artifacts := config.artifactFS()
 	fr := &Framework{
 		config:        &config,
 		KettleAddress: accounts[0],
-		Suave:         &Chain{rpc: kettleRPC, clt: suaveClt, kettleAddr: accounts[0]},
+		Artifacts:     artifacts,
+		Suave:         &Chain{rpc: kettleRPC, clt: suaveClt, kettleAddr: accounts[0], artifacts: artifacts},
 	}
 
 	if config.L1Enabled {
 		l1RPC, err := rpc.Dial(config.L1RPC)
 		if err != nil {
-			panic(err)
+			kettleRPC.Close()
+			return nil, fmt.Errorf("dialing L1: %w", err)
 		}
 		l1Clt := sdk.NewClient(l1RPC, config.FundedAccountL1.Priv, common.Address{})
-		fr.L1 = &Chain{rpc: l1RPC, clt: l1Clt}
+		fr.L1 = &Chain{rpc: l1RPC, clt: l1Clt, artifacts: artifacts}
 	}
 
-	return fr
+	return fr, nil
+}
+
+// Close closes the connections to the kettle and the L1 devnet.
+func (f *Framework) Close() {
+	f.Suave.rpc.Close()
+	if f.L1 != nil {
+		f.L1.rpc.Close()
+	}
+}
+
+// FundedAccount returns the account funded on the SUAVE devnet, see Config.FundedAccount.
+func (f *Framework) FundedAccount() *PrivKey {
+	return f.config.FundedAccount
+}
+
+// FundedAccountL1 returns the account funded on the L1 devnet, see Config.FundedAccountL1.
+func (f *Framework) FundedAccountL1() *PrivKey {
+	return f.config.FundedAccountL1
+}
+
+// ReadArtifact reads an artifact such as "Oracle.sol/Oracle.json" from the configured artifacts.
+func (f *Framework) ReadArtifact(path string) (*Artifact, error) {
+	return ReadArtifactFS(f.Artifacts, path)
 }
 
 type Chain struct {
 	rpc        *rpc.Client
 	clt        *sdk.Client
 	kettleAddr common.Address
+	artifacts  fs.FS
+
+	// sendMu serializes the transactions of the funded account, the sdk assigns the pending nonce
+	sendMu sync.Mutex
 }
 
-func (c *Chain) DeployContract(path string) *Contract {
-	artifact, err := ReadArtifact(path)
+func (c *Chain) DeployContract(ctx context.Context, path string) (*Contract, error) {
+	artifact, err := ReadArtifactFS(c.artifacts, path)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	// deploy contract
 	txnResult, err := sdk.DeployContract(artifact.Code, c.clt)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	receipt, err := txnResult.Wait()
+	receipt, err := WaitForReceipt(ctx, c.clt.RPC(), txnResult.Hash())
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	if receipt.Status == 0 {
-		panic(fmt.Errorf("transaction failed"))
+		return nil, ErrTxFailed
 	}
 
 	log.Printf("deployed contract at %s", receipt.ContractAddress.Hex())
 
 	contract := sdk.GetContract(receipt.ContractAddress, artifact.Abi, c.clt)
-	return &Contract{addr: receipt.ContractAddress, clt: c.clt, kettleAddr: c.kettleAddr, Abi: artifact.Abi, contract: contract}
+	return &Contract{addr: receipt.ContractAddress, clt: c.clt, kettleAddr: c.kettleAddr, Abi: artifact.Abi, contract: contract}, nil
 }
 
 // newly created function (not in sdk)
@@ -290,9 +316,11 @@ func (c *Contract) Ref(acct *PrivKey) *Contract {
 	clt := sdk.NewClient(c.clt.RPC().Client(), acct.Priv, c.kettleAddr)
 
 	cc := &Contract{
-		addr:     c.addr,
-		Abi:      c.Abi,
-		contract: sdk.GetContract(c.addr, c.Abi, clt),
+		addr:       c.addr,
+		clt:        clt,
+		kettleAddr: c.kettleAddr,
+		Abi:        c.Abi,
+		contract:   sdk.GetContract(c.addr, c.Abi, clt),
 	}
 	return cc
 }
@@ -312,8 +340,32 @@ func (c *Chain) RPC() *ethclient.Client {
 	return ethclient.NewClient(c.rpc)
 }
 
-func (c *Chain) FundAccount(to common.Address, value *big.Int) error {
-	balance, err := c.clt.RPC().BalanceAt(context.Background(), c.clt.Addr(), nil)
+// FundAccount sends value to to.
+func (c *Chain) FundAccount(ctx context.Context, to common.Address, value *big.Int) error {
+	current, err := c.clt.RPC().BalanceAt(ctx, to, nil)
+	if err != nil {
+		return err
+	}
+	return c.fund(ctx, to, value, new(big.Int).Add(current, value))
+}
+
+// TopUpAccount tops the balance of to up to target. Nothing is sent if the account already holds
+// that much.
+func (c *Chain) TopUpAccount(ctx context.Context, to common.Address, target *big.Int) error {
+	current, err := c.clt.RPC().BalanceAt(ctx, to, nil)
+	if err != nil {
+		return err
+	}
+	if current.Cmp(target) >= 0 {
+		log.Printf("account %s already holds %s of %s", to.Hex(), current, target)
+		return nil
+	}
+	return c.fund(ctx, to, new(big.Int).Sub(target, current), target)
+}
+
+// fund sends value to to and checks that to holds at least target afterwards
+func (c *Chain) fund(ctx context.Context, to common.Address, value, target *big.Int) error {
+	balance, err := c.clt.RPC().BalanceAt(ctx, c.clt.Addr(), nil)
 	if err != nil {
 		return err
 	}
@@ -325,22 +377,24 @@ func (c *Chain) FundAccount(to common.Address, value *big.Int) error {
 		Value: value,
 		To:    &to,
 	}
+	c.sendMu.Lock()
 	result, err := c.clt.SendTransaction(txn)
+	c.sendMu.Unlock()
 	if err != nil {
 		return err
 	}
 
 	log.Printf("transaction hash: %s", result.Hash().Hex())
-	_, err = result.Wait()
+	_, err = WaitForReceipt(ctx, c.clt.RPC(), result.Hash())
 	if err != nil {
 		return err
 	}
 	// check balance
-	balance, err = c.clt.RPC().BalanceAt(context.Background(), to, nil)
+	balance, err = c.clt.RPC().BalanceAt(ctx, to, nil)
 	if err != nil {
 		return err
 	}
-	if balance.Cmp(value) != 0 {
+	if balance.Cmp(target) < 0 {
 		return errFundAccount
 	}
 	return nil
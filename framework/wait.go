@@ -0,0 +1,102 @@
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ReceiptPollInterval is the time between two receipt lookups in WaitForReceipt.
+var ReceiptPollInterval = time.Second
+
+// TxTiming is the wall-clock profile of a transaction.
+type TxTiming struct {
+	Hash common.Hash
+	// Execution is the time the node took to accept the transaction. For confidential requests
+	// this is the kettle execution including the HTTP calls of the oracle.
+	Execution time.Duration
+	// Confirmation is the time from acceptance until the receipt was available
+	Confirmation time.Duration
+	// PollRounds is the number of lookups until the receipt was available
+	PollRounds int
+}
+
+// TxObserver is notified about the timing of every transaction that was waited for.
+type TxObserver func(timing TxTiming)
+
+type txObserverKey struct{}
+
+// WithTxObserver returns a context that reports the timing of transactions to observer.
+func WithTxObserver(ctx context.Context, observer TxObserver) context.Context {
+	return context.WithValue(ctx, txObserverKey{}, observer)
+}
+
+// ObserveTx reports timing to the observer of ctx, if there is one.
+func ObserveTx(ctx context.Context, timing TxTiming) {
+	if observer, ok := ctx.Value(txObserverKey{}).(TxObserver); ok {
+		observer(timing)
+	}
+}
+
+// WaitForReceipt polls for the receipt of txHash until the transaction is included or ctx is done.
+func WaitForReceipt(ctx context.Context, client *ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
+	return WaitForReceiptTimed(ctx, client, txHash, 0)
+}
+
+// WaitForReceiptTimed is WaitForReceipt for a transaction the node took execution to accept.
+// The timing is reported to the TxObserver of ctx.
+func WaitForReceiptTimed(ctx context.Context, client *ethclient.Client, txHash common.Hash, execution time.Duration) (*types.Receipt, error) {
+	return waitForReceipt(ctx, client, txHash, execution)
+}
+
+// receiptClient is the part of ethclient.Client WaitForReceipt needs
+type receiptClient interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// waitForReceipt polls client until the receipt is available. Failed lookups are retried until ctx
+// is done, a dropped connection or a node that is restarting must not abort the wait. Only a
+// receipt that cannot be decoded is returned as an error right away.
+func waitForReceipt(ctx context.Context, client receiptClient, txHash common.Hash, execution time.Duration) (*types.Receipt, error) {
+	start := time.Now()
+	ticker := time.NewTicker(ReceiptPollInterval)
+	defer ticker.Stop()
+	var lastErr error
+	for rounds := 1; ; rounds++ {
+		receipt, err := client.TransactionReceipt(ctx, txHash)
+		switch {
+		case err == nil:
+			ObserveTx(ctx, TxTiming{Hash: txHash, Execution: execution, Confirmation: time.Since(start), PollRounds: rounds})
+			return receipt, nil
+		case isDecodeError(err):
+			return nil, fmt.Errorf("decoding the receipt of %s: %w", txHash.Hex(), err)
+		case !errors.Is(err, ethereum.NotFound):
+			lastErr = err
+		}
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w, last receipt lookup failed: %v", ctx.Err(), lastErr)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// isDecodeError reports whether err is a response of the node that is no valid receipt. Retrying
+// the lookup returns the same response.
+func isDecodeError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	// types.Receipt reports missing fields without a type of its own
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || strings.Contains(err.Error(), "missing required field")
+}
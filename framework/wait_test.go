@@ -0,0 +1,92 @@
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// receiptResults answers the receipt lookups in order and repeats the last answer
+type receiptResults []struct {
+	receipt *types.Receipt
+	err     error
+}
+
+func (r *receiptResults) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	next := (*r)[0]
+	if len(*r) > 1 {
+		*r = (*r)[1:]
+	}
+	return next.receipt, next.err
+}
+
+func TestWaitForReceipt(t *testing.T) {
+	defer func(interval time.Duration) { ReceiptPollInterval = interval }(ReceiptPollInterval)
+	ReceiptPollInterval = time.Millisecond
+
+	receipt := &types.Receipt{Status: 1}
+	dropped := errors.New("connection reset by peer")
+	decodeErr := fmt.Errorf("decoding: %w", &json.SyntaxError{Offset: 1})
+	for _, tc := range []struct {
+		name    string
+		results receiptResults
+		timeout time.Duration
+
+		receipt *types.Receipt
+		err     error
+	}{
+		{
+			name: "included after a few lookups",
+			results: receiptResults{
+				{err: ethereum.NotFound},
+				{err: ethereum.NotFound},
+				{receipt: receipt},
+			},
+			receipt: receipt,
+		},
+		{
+			name: "transient errors are retried",
+			results: receiptResults{
+				{err: dropped},
+				{err: ethereum.NotFound},
+				{err: dropped},
+				{receipt: receipt},
+			},
+			receipt: receipt,
+		},
+		{
+			name:    "undecodable receipt",
+			results: receiptResults{{err: ethereum.NotFound}, {err: decodeErr}},
+			err:     decodeErr,
+		},
+		{
+			name:    "never included",
+			results: receiptResults{{err: dropped}},
+			timeout: 20 * time.Millisecond,
+			err:     context.DeadlineExceeded,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			got, err := waitForReceipt(ctx, &tc.results, common.Hash{}, 0)
+			if tc.err == nil && err != nil || tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("error %v, expected %v", err, tc.err)
+			}
+			if got != tc.receipt {
+				t.Fatalf("receipt %v, expected %v", got, tc.receipt)
+			}
+		})
+	}
+}
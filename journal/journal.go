@@ -0,0 +1,216 @@
+// Package journal persists the progress of an auction run to a local JSON file, so a crashed
+// run can be resumed and the keys of the ephemeral bidder accounts are never lost.
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Phase is a step of the auction procedure. Phases are completed in the order they are declared.
+type Phase int
+
+const (
+	PhaseNone Phase = iota
+	PhaseDeployed
+	PhaseSetUp
+	PhaseNFTMoved
+	PhaseStarted
+	PhaseBidding
+	PhaseEnded
+	PhaseFinalized
+	PhaseClaimed
+)
+
+var phaseNames = [...]string{"none", "deployed", "set up", "NFT moved", "started", "bidding", "ended", "finalized", "claimed"}
+
+func (p Phase) String() string {
+	if p < 0 || int(p) >= len(phaseNames) {
+		return fmt.Sprintf("Phase(%d)", int(p))
+	}
+	return phaseNames[p]
+}
+
+// Chain names used in TxRecord
+const (
+	ChainSuave = "suave"
+	ChainL1    = "l1"
+)
+
+// TxRecord is a transaction issued during a phase.
+type TxRecord struct {
+	Phase Phase       `json:"phase"`
+	Chain string      `json:"chain"`
+	Hash  common.Hash `json:"hash"`
+}
+
+// Bidder is an ephemeral bidder account created by the driver.
+type Bidder struct {
+	// PrivateKey is hex encoded without 0x prefix. It controls the funds on both chains.
+	PrivateKey string         `json:"privateKey"`
+	Address    common.Address `json:"address"`
+	// FundedL1 and FundedSuave are journaled separately, a resumed run only funds the chain
+	// that is missing. Funded is set once both are done.
+	FundedL1       bool           `json:"fundedL1"`
+	FundedSuave    bool           `json:"fundedSuave"`
+	Funded         bool           `json:"funded"`
+	BiddingAddress common.Address `json:"biddingAddress,omitempty"`
+	BidPlaced      bool           `json:"bidPlaced"`
+	Claimed        bool           `json:"claimed"`
+}
+
+// Run is everything that is needed to continue an auction run.
+type Run struct {
+	ID        string `json:"id"`
+	Variant   string `json:"variant"`
+	NumBidder int    `json:"numBidder"`
+	// Seed the bidder keys are derived from, 0 for random keys
+	Seed int64 `json:"seed,omitempty"`
+	// SeedRun is mixed into the key derivation, e.g. the index of the run in a sweep
+	SeedRun            int64          `json:"seedRun,omitempty"`
+	StartedAt          time.Time      `json:"startedAt"`
+	Phase              Phase          `json:"phase"`
+	OracleAddress      common.Address `json:"oracleAddress,omitempty"`
+	AuctionAddress     common.Address `json:"auctionAddress,omitempty"`
+	NFTContractAddress common.Address `json:"nftContractAddress"`
+	NFTTokenID         *big.Int       `json:"nftTokenId"`
+	AuctionEndTime     *big.Int       `json:"auctionEndTime,omitempty"`
+	NFTHoldingAddress  common.Address `json:"nftHoldingAddress,omitempty"`
+	AuctioneerClaimed  bool           `json:"auctioneerClaimed"`
+	Bidders            []*Bidder      `json:"bidders"`
+	Txs                []TxRecord     `json:"txs"`
+}
+
+// Unclaimed lists who placed a bid, or auctioned the NFT, and has not claimed yet.
+func (r Run) Unclaimed() []string {
+	var unclaimed []string
+	if !r.AuctioneerClaimed {
+		unclaimed = append(unclaimed, "the auctioneer")
+	}
+	for _, bidder := range r.Bidders {
+		if bidder.BidPlaced && !bidder.Claimed {
+			unclaimed = append(unclaimed, bidder.Address.Hex())
+		}
+	}
+	return unclaimed
+}
+
+// Journal is a Run backed by a file. Every Update is written to disk before it returns.
+type Journal struct {
+	path string
+
+	mu  sync.Mutex
+	run Run
+}
+
+// ErrUnfinished is returned by Create if the file contains a run that has not finished yet.
+var ErrUnfinished = errors.New("journal contains an unfinished run")
+
+// Create starts a new journal at path. It refuses to replace the journal of an unfinished run or
+// of a run with unclaimed funds, as that is the only record of its bidder keys.
+func Create(path string, run Run) (*Journal, error) {
+	existing, err := Open(path)
+	switch {
+	case err == nil && existing.run.Phase != PhaseClaimed:
+		return nil, fmt.Errorf("%w in %s (phase %s); resume it or remove the file", ErrUnfinished, path, existing.run.Phase)
+	case err == nil && len(existing.run.Unclaimed()) > 0:
+		return nil, fmt.Errorf("%w in %s, %s did not claim; resume it or remove the file", ErrUnfinished, path, strings.Join(existing.run.Unclaimed(), ", "))
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+	j := &Journal{path: path, run: run}
+	if err := j.save(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Open loads an existing journal.
+func Open(path string) (*Journal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	j := &Journal{path: path}
+	if err := json.Unmarshal(data, &j.run); err != nil {
+		return nil, fmt.Errorf("decoding journal %s: %w", path, err)
+	}
+	return j, nil
+}
+
+// Path returns the file the journal is stored in.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Run returns a copy of the journaled run. Bidders are shared with the journal and must only be
+// modified inside Update.
+func (j *Journal) Run() Run {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	run := j.run
+	run.Bidders = append([]*Bidder(nil), j.run.Bidders...)
+	run.Txs = append([]TxRecord(nil), j.run.Txs...)
+	return run
+}
+
+// Reached reports whether phase has been completed.
+func (j *Journal) Reached(phase Phase) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.run.Phase >= phase
+}
+
+// Update modifies the run and persists it.
+func (j *Journal) Update(fn func(run *Run)) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.run)
+	return j.save()
+}
+
+// Complete marks phase as completed.
+func (j *Journal) Complete(phase Phase) error {
+	return j.Update(func(run *Run) {
+		if run.Phase < phase {
+			run.Phase = phase
+		}
+	})
+}
+
+// RecordTx appends a transaction to the run.
+func (j *Journal) RecordTx(phase Phase, chain string, hash common.Hash) error {
+	return j.Update(func(run *Run) {
+		run.Txs = append(run.Txs, TxRecord{Phase: phase, Chain: chain, Hash: hash})
+	})
+}
+
+// save writes the journal to a temporary file first, so a crash never leaves a truncated journal behind.
+// The caller holds j.mu.
+func (j *Journal) save() error {
+	data, err := json.MarshalIndent(&j.run, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(j.path); dir != "." {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+	}
+	tmp := j.path + ".tmp"
+	// the journal contains private keys
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
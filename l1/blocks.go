@@ -0,0 +1,42 @@
+package l1
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// BlockAtTime searches the headers of client for the last block with a timestamp at or before
+// timestamp, like Etherscan's getblocknobytime with closest=before, or for the first block at or
+// after it. It reports false if there is no such block.
+func BlockAtTime(ctx context.Context, client *ethclient.Client, timestamp uint64, before bool) (uint64, bool, error) {
+	latest, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	// find the first block with a time after timestamp (before) or at least timestamp (after)
+	lo, hi := uint64(0), latest.Number.Uint64()+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, false, err
+		}
+		if header.Time > timestamp || (!before && header.Time == timestamp) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if before {
+		if lo == 0 {
+			return 0, false, nil
+		}
+		return lo - 1, true, nil
+	}
+	if lo > latest.Number.Uint64() {
+		return 0, false, nil
+	}
+	return lo, true, nil
+}
@@ -0,0 +1,489 @@
+// Package l1 sends the transactions of the driver to the L1 chain. The Manager owns the nonces of
+// every sender, builds EIP-1559 transactions from a single FeePolicy and replaces transactions
+// that are not included in time with bumped fees.
+package l1
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrInsufficientBalance is returned for a sweep if the balance does not cover the gas
+	ErrInsufficientBalance = errors.New("insufficient balance to cover the gas")
+	// ErrNonceConsumed is returned if a transaction that is not known to the manager was included
+	// with the nonce of a pending transaction
+	ErrNonceConsumed = errors.New("nonce was consumed by another transaction")
+	// ErrStuck is returned if a transaction is still not included after MaxBumps replacements
+	ErrStuck = errors.New("transaction is stuck")
+)
+
+// Client is the part of ethclient.Client the Manager uses.
+type Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// FeePolicy derives the fees of all transactions.
+type FeePolicy struct {
+	// MinTipCap is the lowest priority fee, the suggestion of the node is used if it is higher
+	MinTipCap *big.Int
+	// BaseFeeMultiplier leaves room for rising base fees: feeCap = BaseFeeMultiplier * baseFee + tip
+	BaseFeeMultiplier int64
+	// BumpPercent is the fee increase of a replacement, nodes require at least 10
+	BumpPercent int64
+	// MaxFeeCap limits the fee cap of bumped transactions, nil for no limit
+	MaxFeeCap *big.Int
+}
+
+// DefaultFeePolicy pays a tip of at least 1.5 Gwei and bumps replacements by 20%.
+func DefaultFeePolicy() FeePolicy {
+	return FeePolicy{
+		MinTipCap:         big.NewInt(1500000000),
+		BaseFeeMultiplier: 2,
+		BumpPercent:       20,
+	}
+}
+
+// fees returns the tip and fee cap for a new transaction
+func (p FeePolicy) fees(ctx context.Context, client Client) (tip, feeCap *big.Int, err error) {
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	tip, err = client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.MinTipCap != nil && tip.Cmp(p.MinTipCap) < 0 {
+		tip = new(big.Int).Set(p.MinTipCap)
+	}
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = new(big.Int)
+	}
+	feeCap = new(big.Int).Mul(baseFee, big.NewInt(max(p.BaseFeeMultiplier, 1)))
+	feeCap.Add(feeCap, tip)
+	return tip, p.limit(feeCap), nil
+}
+
+// bump raises the fees of a replacement by BumpPercent, or to the current fees if they are higher
+func (p FeePolicy) bump(ctx context.Context, client Client, tip, feeCap *big.Int) (*big.Int, *big.Int, error) {
+	currentTip, currentFeeCap, err := p.fees(ctx, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.bumpFees(tip, feeCap, currentTip, currentFeeCap)
+}
+
+// bumpFees is bump for the given current fees
+func (p FeePolicy) bumpFees(tip, feeCap, currentTip, currentFeeCap *big.Int) (*big.Int, *big.Int, error) {
+	percent := big.NewInt(100 + max(p.BumpPercent, 10))
+	bumpedTip := new(big.Int).Div(new(big.Int).Mul(tip, percent), big.NewInt(100))
+	bumpedFeeCap := new(big.Int).Div(new(big.Int).Mul(feeCap, percent), big.NewInt(100))
+	if currentTip.Cmp(bumpedTip) > 0 {
+		bumpedTip = currentTip
+	}
+	if currentFeeCap.Cmp(bumpedFeeCap) > 0 {
+		bumpedFeeCap = currentFeeCap
+	}
+	if bumpedFeeCap.Cmp(bumpedTip) < 0 {
+		bumpedFeeCap = new(big.Int).Set(bumpedTip)
+	}
+	if p.MaxFeeCap != nil && bumpedFeeCap.Cmp(p.MaxFeeCap) > 0 {
+		return nil, nil, fmt.Errorf("%w: replacement would exceed the maximum fee cap of %s", ErrStuck, p.MaxFeeCap)
+	}
+	return bumpedTip, bumpedFeeCap, nil
+}
+
+func (p FeePolicy) limit(feeCap *big.Int) *big.Int {
+	if p.MaxFeeCap != nil && feeCap.Cmp(p.MaxFeeCap) > 0 {
+		return new(big.Int).Set(p.MaxFeeCap)
+	}
+	return feeCap
+}
+
+// Request describes a transaction to send.
+type Request struct {
+	From *ecdsa.PrivateKey
+	To   common.Address
+	// Value is ignored for a sweep
+	Value *big.Int
+	Data  []byte
+	// Gas limit; 0 estimates it
+	Gas uint64
+	// Sweep sends the whole balance of From minus the maximum gas costs
+	Sweep bool
+}
+
+// Result is the outcome of a transaction that was waited for.
+type Result struct {
+	Receipt *types.Receipt
+	// Tx is the transaction that was included, a replacement if the original got stuck
+	Tx *types.Transaction
+	// Replacements is the number of fee bumps
+	Replacements int
+	// Execution is how long the node took to accept the first broadcast
+	Execution time.Duration
+	// Confirmation is the time from the first broadcast until the receipt was available
+	Confirmation time.Duration
+	// PollRounds is the number of receipt lookups
+	PollRounds int
+}
+
+// Manager sends transactions for any number of senders. It is safe for concurrent use.
+type Manager struct {
+	client  Client
+	chainID *big.Int
+	policy  FeePolicy
+
+	// PollInterval is the time between two receipt lookups
+	PollInterval time.Duration
+	// StuckAfter is how long a transaction may stay pending before it is replaced
+	StuckAfter time.Duration
+	// MaxBumps limits the replacements of a transaction
+	MaxBumps int
+
+	mu      sync.Mutex
+	senders map[common.Address]*sender
+}
+
+// sender tracks the next nonce of an account
+type sender struct {
+	mu    sync.Mutex
+	next  uint64
+	known bool
+}
+
+// NewManager creates a manager for the chain with chainID.
+func NewManager(client Client, chainID *big.Int, policy FeePolicy) *Manager {
+	return &Manager{
+		client:       client,
+		chainID:      chainID,
+		policy:       policy,
+		PollInterval: 5 * time.Second,
+		StuckAfter:   3 * time.Minute,
+		MaxBumps:     5,
+		senders:      map[common.Address]*sender{},
+	}
+}
+
+func (m *Manager) sender(addr common.Address) *sender {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.senders[addr]
+	if !ok {
+		s = &sender{}
+		m.senders[addr] = s
+	}
+	return s
+}
+
+// Pending is a transaction that was sent but not waited for yet.
+type Pending struct {
+	m         *Manager
+	req       Request
+	tx        *types.Transaction
+	execution time.Duration
+}
+
+// Hash returns the hash of the first version of the transaction.
+func (p *Pending) Hash() common.Hash {
+	return p.tx.Hash()
+}
+
+// Send broadcasts the transaction of req and waits until it or one of its replacements is included.
+func (m *Manager) Send(ctx context.Context, req Request) (*Result, error) {
+	pending, err := m.Submit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return pending.Wait(ctx)
+}
+
+// Submit assigns the next nonce of the sender and broadcasts the first version of the transaction.
+// The nonces of a sender are assigned one at a time and counted locally, because the pending nonce
+// of a load balanced RPC lags behind the transactions that were just sent.
+func (m *Manager) Submit(ctx context.Context, req Request) (*Pending, error) {
+	tx, execution, err := m.submit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Pending{m: m, req: req, tx: tx, execution: execution}, nil
+}
+
+func (m *Manager) submit(ctx context.Context, req Request) (*types.Transaction, time.Duration, error) {
+	from := crypto.PubkeyToAddress(req.From.PublicKey)
+	s := m.sender(from)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.known {
+		// start after the pending transactions, they might have been sent by someone else. A
+		// lagging node never moves the local nonce back onto a transaction still in flight.
+		pending, err := m.client.PendingNonceAt(ctx, from)
+		if err != nil {
+			return nil, 0, err
+		}
+		s.next, s.known = max(pending, s.next), true
+	}
+	tip, feeCap, err := m.policy.fees(ctx, m.client)
+	if err != nil {
+		return nil, 0, err
+	}
+	for attempts := 0; ; attempts++ {
+		tx, err := m.sign(ctx, req, s.next, tip, feeCap)
+		if err != nil {
+			return nil, 0, err
+		}
+		sent := time.Now()
+		err = m.client.SendTransaction(ctx, tx)
+		switch {
+		case err == nil:
+			log.Printf("L1 transaction %s sent by %s with nonce %d", tx.Hash().Hex(), from.Hex(), tx.Nonce())
+			s.next++
+			return tx, time.Since(sent), nil
+		case isAlreadyKnown(err):
+			// this very transaction reached the node before, e.g. by a send that timed out
+			log.Printf("L1 transaction %s of %s with nonce %d is already known", tx.Hash().Hex(), from.Hex(), tx.Nonce())
+			s.next++
+			return tx, time.Since(sent), nil
+		case (isReplacementUnderpriced(err) || isNonceTooLow(err)) && attempts < m.MaxBumps:
+			// another transaction took the nonce, only transactions of this manager are replaced
+			log.Printf("Nonce %d of %s is taken: %v", s.next, from.Hex(), err)
+			pending, err := m.client.PendingNonceAt(ctx, from)
+			if err != nil {
+				return nil, 0, err
+			}
+			s.next = max(pending, s.next+1)
+		case isUnderpriced(err) && attempts < m.MaxBumps:
+			// the fees are below the minimum of the node
+			if tip, feeCap, err = m.policy.bump(ctx, m.client, tip, feeCap); err != nil {
+				return nil, 0, err
+			}
+		default:
+			// the node might know better next time, e.g. after a transaction of the account was sent elsewhere
+			s.known = false
+			return nil, 0, err
+		}
+	}
+}
+
+// sign builds and signs the EIP-1559 transaction of req
+func (m *Manager) sign(ctx context.Context, req Request, nonce uint64, tip, feeCap *big.Int) (*types.Transaction, error) {
+	from := crypto.PubkeyToAddress(req.From.PublicKey)
+	gas := req.Gas
+	if gas == 0 {
+		msg := ethereum.CallMsg{From: from, To: &req.To, Value: req.Value, Data: req.Data}
+		estimate, err := m.client.EstimateGas(ctx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("estimating gas: %w", err)
+		}
+		gas = estimate
+	}
+	value := req.Value
+	if req.Sweep {
+		balance, err := m.client.BalanceAt(ctx, from, nil)
+		if err != nil {
+			return nil, err
+		}
+		value = new(big.Int).Sub(balance, new(big.Int).Mul(feeCap, new(big.Int).SetUint64(gas)))
+		if value.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: balance=%s, fee cap=%s, gas=%d", ErrInsufficientBalance, balance, feeCap, gas)
+		}
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   m.chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        &req.To,
+		Value:     value,
+		Data:      req.Data,
+	})
+	return types.SignTx(tx, types.LatestSignerForChainID(m.chainID), req.From)
+}
+
+// Wait polls until one of the versions of the transaction is included and replaces it with bumped
+// fees whenever it was pending for StuckAfter.
+func (p *Pending) Wait(ctx context.Context) (result *Result, err error) {
+	m, req, tx := p.m, p.req, p.tx
+	from := crypto.PubkeyToAddress(req.From.PublicKey)
+	defer func() {
+		// the transaction might never be included, let the next transaction of the sender check
+		// whether the node is ahead of the local nonce
+		if err != nil {
+			m.forget(from)
+		}
+	}()
+	start := time.Now()
+	lastSent := start
+	versions := []*types.Transaction{tx}
+	consumed := 0
+	ticker := time.NewTicker(m.PollInterval)
+	defer ticker.Stop()
+	for rounds := 1; ; rounds++ {
+		for _, version := range versions {
+			receipt, err := m.client.TransactionReceipt(ctx, version.Hash())
+			if err == nil {
+				return &Result{
+					Receipt:      receipt,
+					Tx:           version,
+					Replacements: len(versions) - 1,
+					Execution:    p.execution,
+					Confirmation: time.Since(start),
+					PollRounds:   rounds,
+				}, nil
+			}
+			if !errors.Is(err, ethereum.NotFound) {
+				return nil, err
+			}
+		}
+		confirmed, err := m.client.NonceAt(ctx, from, nil)
+		if err != nil {
+			return nil, err
+		}
+		if confirmed > tx.Nonce() {
+			// the receipt might lag behind the nonce, check once more before giving up
+			if consumed++; consumed > 1 {
+				return nil, fmt.Errorf("%w: nonce %d of %s", ErrNonceConsumed, tx.Nonce(), from.Hex())
+			}
+		} else if time.Since(lastSent) >= m.StuckAfter {
+			if len(versions) > m.MaxBumps {
+				return nil, fmt.Errorf("%w: %s not included after %d replacements", ErrStuck, tx.Hash().Hex(), m.MaxBumps)
+			}
+			latest := versions[len(versions)-1]
+			replacement, err := m.replace(ctx, req, latest)
+			if err != nil {
+				return nil, err
+			}
+			if replacement != nil {
+				versions = append(versions, replacement)
+			}
+			lastSent = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// replace sends latest again with bumped fees. It returns nil if the node already knows a
+// transaction with that nonce that is at least as expensive.
+func (m *Manager) replace(ctx context.Context, req Request, latest *types.Transaction) (*types.Transaction, error) {
+	tip, feeCap, err := m.policy.bump(ctx, m.client, latest.GasTipCap(), latest.GasFeeCap())
+	if err != nil {
+		return nil, err
+	}
+	req.Gas = latest.Gas()
+	tx, err := m.sign(ctx, req, latest.Nonce(), tip, feeCap)
+	if err != nil {
+		return nil, err
+	}
+	err = m.client.SendTransaction(ctx, tx)
+	switch {
+	case err == nil:
+		log.Printf("L1 transaction %s stuck, replaced by %s with fee cap %s and tip %s", latest.Hash().Hex(), tx.Hash().Hex(), feeCap, tip)
+		return tx, nil
+	case isUnderpriced(err), isNonceTooLow(err), isAlreadyKnown(err):
+		log.Printf("Replacing L1 transaction %s failed: %v", latest.Hash().Hex(), err)
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+// forget makes the next transaction of addr read the pending nonce from the node again. The local
+// nonce is only raised to it, never lowered.
+func (m *Manager) forget(addr common.Address) {
+	s := m.sender(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.known = false
+}
+
+// SendSigned sends a transaction signed elsewhere without waiting for it. It is no error if the
+// node already knows the transaction.
+func (m *Manager) SendSigned(ctx context.Context, tx *types.Transaction) error {
+	if err := m.client.SendTransaction(ctx, tx); err != nil && !isAlreadyKnown(err) {
+		return err
+	}
+	return nil
+}
+
+// Broadcast sends a transaction signed elsewhere, e.g. by the oracle, and waits for it.
+func (m *Manager) Broadcast(ctx context.Context, tx *types.Transaction) (*Result, error) {
+	sent := time.Now()
+	if err := m.SendSigned(ctx, tx); err != nil {
+		return nil, err
+	}
+	execution := time.Since(sent)
+	result, err := m.Wait(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	result.Execution = execution
+	return result, nil
+}
+
+// Wait polls for the receipt of a transaction sent by someone else. It can not be replaced.
+func (m *Manager) Wait(ctx context.Context, txHash common.Hash) (*Result, error) {
+	start := time.Now()
+	ticker := time.NewTicker(m.PollInterval)
+	defer ticker.Stop()
+	for rounds := 1; ; rounds++ {
+		receipt, err := m.client.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return &Result{Receipt: receipt, Confirmation: time.Since(start), PollRounds: rounds}, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// the nodes report these conditions only as error messages
+func isUnderpriced(err error) bool {
+	return strings.Contains(err.Error(), "underpriced")
+}
+
+func isReplacementUnderpriced(err error) bool {
+	return strings.Contains(err.Error(), "replacement transaction underpriced")
+}
+
+func isNonceTooLow(err error) bool {
+	return strings.Contains(err.Error(), "nonce too low")
+}
+
+func isAlreadyKnown(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
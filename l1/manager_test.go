@@ -0,0 +1,273 @@
+package l1
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeClient is a node whose SendTransaction answers with the queued errors, nil once they are used
+type fakeClient struct {
+	mu sync.Mutex
+	// pending is returned by PendingNonceAt, confirmed by NonceAt
+	pending, confirmed uint64
+	sendErrs           []error
+	sent               []*types.Transaction
+	// includeFrom is the number of sends after which a sent transaction is included, 0 never
+	includeFrom int
+	included    map[common.Hash]bool
+}
+
+func (c *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(10000000000)}, nil
+}
+
+func (c *fakeClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1000000000), nil
+}
+
+func (c *fakeClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return 21000, nil
+}
+
+func (c *fakeClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return big.NewInt(1e18), nil
+}
+
+func (c *fakeClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.confirmed, nil
+}
+
+func (c *fakeClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pending, nil
+}
+
+func (c *fakeClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, tx)
+	if c.includeFrom > 0 && len(c.sent) >= c.includeFrom {
+		if c.included == nil {
+			c.included = map[common.Hash]bool{}
+		}
+		c.included[tx.Hash()] = true
+	}
+	if len(c.sendErrs) > 0 {
+		err := c.sendErrs[0]
+		c.sendErrs = c.sendErrs[1:]
+		return err
+	}
+	return nil
+}
+
+func (c *fakeClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.included[txHash] {
+		return nil, ethereum.NotFound
+	}
+	return &types.Receipt{TxHash: txHash, Status: types.ReceiptStatusSuccessful}, nil
+}
+
+func newTestManager(t *testing.T, client *fakeClient) (*Manager, Request) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager(client, big.NewInt(1337), DefaultFeePolicy())
+	m.PollInterval = time.Millisecond
+	return m, Request{From: key, To: common.Address{0x01}, Value: big.NewInt(1), Gas: 21000}
+}
+
+func TestSubmitNonces(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		pending  uint64
+		sendErrs []error
+		// nonces of the sent transactions, the last one is returned
+		nonces []uint64
+	}{
+		{name: "pending nonce", pending: 4, nonces: []uint64{4}},
+		{
+			name:     "nonce taken by another transaction",
+			pending:  4,
+			sendErrs: []error{errors.New("nonce too low")},
+			nonces:   []uint64{4, 5},
+		},
+		{
+			name:     "pending transaction of someone else",
+			pending:  4,
+			sendErrs: []error{errors.New("replacement transaction underpriced")},
+			nonces:   []uint64{4, 5},
+		},
+		{
+			name:     "already known",
+			pending:  4,
+			sendErrs: []error{errors.New("already known")},
+			nonces:   []uint64{4},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeClient{pending: tc.pending, sendErrs: tc.sendErrs}
+			m, req := newTestManager(t, client)
+			pending, err := m.Submit(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(client.sent) != len(tc.nonces) {
+				t.Fatalf("%d transactions sent, expected %d", len(client.sent), len(tc.nonces))
+			}
+			for i, tx := range client.sent {
+				if tx.Nonce() != tc.nonces[i] {
+					t.Errorf("transaction %d has nonce %d, expected %d", i, tx.Nonce(), tc.nonces[i])
+				}
+			}
+			if pending.tx.Nonce() != tc.nonces[len(tc.nonces)-1] {
+				t.Errorf("submitted nonce %d, expected %d", pending.tx.Nonce(), tc.nonces[len(tc.nonces)-1])
+			}
+			if next := m.sender(crypto.PubkeyToAddress(req.From.PublicKey)).next; next != pending.tx.Nonce()+1 {
+				t.Errorf("next nonce %d, expected %d", next, pending.tx.Nonce()+1)
+			}
+		})
+	}
+}
+
+func TestResyncKeepsLocalNonce(t *testing.T) {
+	client := &fakeClient{pending: 4}
+	m, req := newTestManager(t, client)
+	from := crypto.PubkeyToAddress(req.From.PublicKey)
+	for i := 0; i < 3; i++ {
+		if _, err := m.Submit(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the node lags behind the transactions in flight
+	m.forget(from)
+	client.pending = 5
+	pending, err := m.Submit(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.tx.Nonce() != 7 {
+		t.Fatalf("nonce %d after the resync with a lagging node, expected 7", pending.tx.Nonce())
+	}
+
+	// the node is ahead, e.g. the account sent transactions elsewhere
+	m.forget(from)
+	client.pending = 12
+	if pending, err = m.Submit(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if pending.tx.Nonce() != 12 {
+		t.Fatalf("nonce %d after the resync with a node that is ahead, expected 12", pending.tx.Nonce())
+	}
+}
+
+func TestWaitReplacesStuckTransaction(t *testing.T) {
+	// the first replacement is included
+	client := &fakeClient{includeFrom: 2}
+	m, req := newTestManager(t, client)
+	m.StuckAfter = 0
+	pending, err := m.Submit(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := pending.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Replacements != 1 || result.Tx != client.sent[1] || result.Tx.Nonce() != pending.tx.Nonce() {
+		t.Fatalf("included %s with nonce %d after %d replacements", result.Tx.Hash().Hex(), result.Tx.Nonce(), result.Replacements)
+	}
+	if result.Tx.GasFeeCap().Cmp(pending.tx.GasFeeCap()) <= 0 || result.Tx.GasTipCap().Cmp(pending.tx.GasTipCap()) <= 0 {
+		t.Fatalf("replacement fees %s/%s not above %s/%s", result.Tx.GasTipCap(), result.Tx.GasFeeCap(), pending.tx.GasTipCap(), pending.tx.GasFeeCap())
+	}
+}
+
+func TestBumpFees(t *testing.T) {
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1000000000)) }
+	for _, tc := range []struct {
+		name             string
+		policy           FeePolicy
+		tip, feeCap      *big.Int
+		currentTip       *big.Int
+		currentFeeCap    *big.Int
+		wantTip, wantCap *big.Int
+		wantStuck        bool
+	}{
+		{
+			name:   "bumped by BumpPercent",
+			policy: FeePolicy{BumpPercent: 20},
+			tip:    gwei(10), feeCap: gwei(50),
+			currentTip: gwei(1), currentFeeCap: gwei(20),
+			wantTip: gwei(12), wantCap: gwei(60),
+		},
+		{
+			name:   "at least the 10% nodes require",
+			policy: FeePolicy{BumpPercent: 5},
+			tip:    gwei(10), feeCap: gwei(50),
+			currentTip: gwei(1), currentFeeCap: gwei(20),
+			wantTip: gwei(11), wantCap: gwei(55),
+		},
+		{
+			name:   "current fees above the bump",
+			policy: FeePolicy{BumpPercent: 20},
+			tip:    gwei(10), feeCap: gwei(50),
+			currentTip: gwei(15), currentFeeCap: gwei(100),
+			wantTip: gwei(15), wantCap: gwei(100),
+		},
+		{
+			name:   "fee cap raised to the tip",
+			policy: FeePolicy{BumpPercent: 20},
+			tip:    gwei(10), feeCap: gwei(10),
+			currentTip: gwei(30), currentFeeCap: gwei(20),
+			wantTip: gwei(30), wantCap: gwei(30),
+		},
+		{
+			name:   "within the maximum fee cap",
+			policy: FeePolicy{BumpPercent: 20, MaxFeeCap: gwei(60)},
+			tip:    gwei(10), feeCap: gwei(50),
+			currentTip: gwei(1), currentFeeCap: gwei(20),
+			wantTip: gwei(12), wantCap: gwei(60),
+		},
+		{
+			name:   "above the maximum fee cap",
+			policy: FeePolicy{BumpPercent: 20, MaxFeeCap: gwei(59)},
+			tip:    gwei(10), feeCap: gwei(50),
+			currentTip: gwei(1), currentFeeCap: gwei(20),
+			wantStuck: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tip, feeCap, err := tc.policy.bumpFees(tc.tip, tc.feeCap, tc.currentTip, tc.currentFeeCap)
+			if tc.wantStuck {
+				if !errors.Is(err, ErrStuck) {
+					t.Fatalf("error %v, expected %v", err, ErrStuck)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tip.Cmp(tc.wantTip) != 0 || feeCap.Cmp(tc.wantCap) != 0 {
+				t.Fatalf("bumped to tip %s and fee cap %s, expected %s and %s", tip, feeCap, tc.wantTip, tc.wantCap)
+			}
+		})
+	}
+}
@@ -1,31 +1,255 @@
+// Command measure runs a matrix of auctions in-process and records their gas costs. Every sweep
+// gets its own directory containing the spec, the gas records of all runs and one result per run.
+//
+//	go run ./measurements --variants base,proposer --bidders 1-5 --iterations 3
+//	go run ./measurements --spec sweep.json
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
 	"os"
-	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"suave/sealedauction/driver"
+)
+
+// Spec is the matrix of a sweep. Every combination of variant, bidder count and seed is run
+// Iterations times.
+type Spec struct {
+	Variants   []string `json:"variants"`
+	Bidders    []int    `json:"bidders"`
+	Iterations int      `json:"iterations"`
+	// Seeds for the bidder keys, 0 generates random keys
+	Seeds []int64 `json:"seeds"`
+	// PhaseTimeout is a duration like "10m"
+	PhaseTimeout string `json:"phaseTimeout,omitempty"`
+	// BidConcurrency is the number of bidders that bid at the same time, 1 if unset
+	BidConcurrency int `json:"bidConcurrency,omitempty"`
+}
+
+// Result is the outcome of a single run of the sweep.
+type Result struct {
+	Variant   string    `json:"variant"`
+	Bidders   int       `json:"bidders"`
+	Iteration int       `json:"iteration"`
+	Seed      int64     `json:"seed"`
+	RunID     string    `json:"runId"`
+	Journal   string    `json:"journal"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+}
+
+const (
+	statusOK     = "ok"
+	statusFailed = "failed"
 )
 
 func main() {
-	useProposer := true // adapt to measure Proposer version
-	var cmd (*exec.Cmd)
-	for num_bidder := 1; num_bidder <= 5; num_bidder++ {
-
-		for i := 1; i <= 1; i++ { // adapt iterations to run multiple times
-			fmt.Printf("Starting iteration %d...\n", i)
-			if useProposer {
-				cmd = exec.Command("go", "run", "src/ProposerVersion/main.go", fmt.Sprint(num_bidder))
-			} else {
-				cmd = exec.Command("go", "run", "main.go", fmt.Sprint(num_bidder))
+	specPath := flag.String("spec", "", "JSON file with the sweep matrix; overrides the matrix flags")
+	variants := flag.String("variants", "proposer", "comma separated auction variants")
+	bidders := flag.String("bidders", "1-5", "comma separated bidder counts or ranges, e.g. 1,2,4-6")
+	iterations := flag.Int("iterations", 1, "runs per combination")
+	seeds := flag.String("seeds", "0", "comma separated seeds for the bidder keys, 0 generates random keys")
+	phaseTimeout := flag.String("phase-timeout", "", "deadline for each auction phase, e.g. 10m")
+	bidConcurrency := flag.Int("bid-concurrency", 1, "number of bidders that are funded and bid at the same time")
+	outDir := flag.String("out", "sweeps", "directory the sweep results are written to")
+	flag.Parse()
+
+	spec, err := loadSpec(*specPath, *variants, *bidders, *iterations, *seeds, *phaseTimeout, *bidConcurrency)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// abort the sweep on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	failed, err := sweep(ctx, spec, *outDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed > 0 {
+		log.Printf("%d runs failed", failed)
+		os.Exit(1)
+	}
+}
+
+func loadSpec(path, variants, bidders string, iterations int, seeds, phaseTimeout string, bidConcurrency int) (*Spec, error) {
+	spec := &Spec{Iterations: iterations, PhaseTimeout: phaseTimeout, BidConcurrency: bidConcurrency}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, spec); err != nil {
+			return nil, fmt.Errorf("decoding spec %s: %w", path, err)
+		}
+	} else {
+		spec.Variants = strings.Split(variants, ",")
+		var err error
+		if spec.Bidders, err = parseInts(bidders); err != nil {
+			return nil, fmt.Errorf("bidders: %w", err)
+		}
+		seedInts, err := parseInts(seeds)
+		if err != nil {
+			return nil, fmt.Errorf("seeds: %w", err)
+		}
+		for _, seed := range seedInts {
+			spec.Seeds = append(spec.Seeds, int64(seed))
+		}
+	}
+	if len(spec.Seeds) == 0 {
+		spec.Seeds = []int64{0}
+	}
+	if spec.Iterations < 1 {
+		return nil, errors.New("iterations must be at least 1")
+	}
+	if len(spec.Bidders) == 0 {
+		return nil, errors.New("no bidder counts given")
+	}
+	for _, name := range spec.Variants {
+		if _, err := driver.VariantByName(name); err != nil {
+			return nil, err
+		}
+	}
+	if spec.PhaseTimeout != "" {
+		if _, err := time.ParseDuration(spec.PhaseTimeout); err != nil {
+			return nil, fmt.Errorf("phase timeout: %w", err)
+		}
+	}
+	return spec, nil
+}
+
+// parseInts parses "1,2,4-6"
+func parseInts(list string) ([]int, error) {
+	var ints []int
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(to); err != nil {
+				return nil, err
 			}
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			err := cmd.Run()
-			if err != nil {
-				fmt.Printf("Iteration %d failed: %v\n", i, err)
-				return
+		}
+		for i := first; i <= last; i++ {
+			ints = append(ints, i)
+		}
+	}
+	return ints, nil
+}
+
+// sweep runs every combination of the spec and returns the number of failed runs. Failed runs are
+// recorded and the sweep continues; only cancelling ctx aborts it.
+func sweep(ctx context.Context, spec *Spec, outDir string) (int, error) {
+	dir := filepath.Join(outDir, time.Now().UTC().Format("20060102-150405"))
+	if err := os.MkdirAll(filepath.Join(dir, "journals"), 0700); err != nil {
+		return 0, err
+	}
+	specData, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "spec.json"), specData, 0644); err != nil {
+		return 0, err
+	}
+	resultsFile, err := os.Create(filepath.Join(dir, "runs.jsonl"))
+	if err != nil {
+		return 0, err
+	}
+	defer resultsFile.Close()
+	results := json.NewEncoder(resultsFile)
+
+	var phaseTimeout time.Duration
+	if spec.PhaseTimeout != "" {
+		phaseTimeout, _ = time.ParseDuration(spec.PhaseTimeout)
+	}
+	baseConfig := driver.LoadConfig()
+	failed := 0
+	n := 0
+	for _, variantName := range spec.Variants {
+		variant, err := driver.VariantByName(variantName)
+		if err != nil {
+			return failed, err
+		}
+		for _, numBidder := range spec.Bidders {
+			for _, seed := range spec.Seeds {
+				for iteration := 1; iteration <= spec.Iterations; iteration++ {
+					n++
+					config := *baseConfig
+					config.PhaseTimeout = phaseTimeout
+					config.Seed = seed
+					config.SeedRun = int64(n)
+					config.BidConcurrency = max(spec.BidConcurrency, 1)
+					config.JournalPath = filepath.Join(dir, "journals", fmt.Sprintf("%03d.json", n))
+					config.MetricsPath = filepath.Join(dir, "records.jsonl")
+					config.WriteToFile = false
+
+					fmt.Printf("Run %d: %s auction with %d bidders, seed %d, iteration %d\n", n, variantName, numBidder, seed, iteration)
+					result := Result{
+						Variant:   variantName,
+						Bidders:   numBidder,
+						Iteration: iteration,
+						Seed:      seed,
+						Journal:   config.JournalPath,
+						StartedAt: time.Now().UTC(),
+					}
+					runID, err := run(ctx, &config, variant, numBidder)
+					result.RunID = runID
+					result.Duration = time.Since(result.StartedAt).Round(time.Second).String()
+					result.Status = statusOK
+					if err != nil {
+						result.Status = statusFailed
+						result.Error = err.Error()
+						failed++
+						log.Printf("Run %d failed: %v", n, err)
+					}
+					if err := results.Encode(result); err != nil {
+						return failed, err
+					}
+					if ctx.Err() != nil {
+						return failed, ctx.Err()
+					}
+				}
 			}
-			fmt.Printf("Iteration %d finished.\n", i)
 		}
 	}
+	fmt.Printf("Sweep finished with %d of %d runs failed, results in %s\n", failed, n, dir)
+	return failed, nil
+}
+
+// run executes a single auction. A panic of the SDK is recovered and returned as the error of the
+// run, so one broken run does not end the sweep.
+func run(ctx context.Context, config *driver.Config, variant driver.Variant, numBidder int) (runID string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("run panicked: %v", r)
+		}
+	}()
+	d, err := driver.New(config, variant)
+	if err != nil {
+		return "", err
+	}
+	defer d.Close()
+	err = d.Run(ctx, numBidder)
+	return d.RunID(), err
 }
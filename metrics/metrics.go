@@ -0,0 +1,210 @@
+// Package metrics records the gas costs of auction runs as structured records. Records are written
+// to one or more sinks: JSONL, CSV or the legacy text format of measurements.txt.
+package metrics
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Chain a transaction was executed on
+type Chain string
+
+const (
+	ChainSuave Chain = "SUAVE"
+	ChainL1    Chain = "L1"
+)
+
+// Phase of the auction a transaction belongs to
+type Phase string
+
+const (
+	PhaseDeploy         Phase = "deploy"
+	PhaseSetUp          Phase = "setup"
+	PhaseMoveNFT        Phase = "move_nft"
+	PhaseStart          Phase = "start"
+	PhaseBiddingAddress Phase = "bidding_address"
+	PhaseEnd            Phase = "end"
+	PhaseRefute         Phase = "refute"
+	PhaseClaim          Phase = "claim"
+)
+
+// Phases lists all phases in the order they occur in a run.
+var Phases = []Phase{PhaseDeploy, PhaseSetUp, PhaseMoveNFT, PhaseStart, PhaseBiddingAddress, PhaseEnd, PhaseRefute, PhaseClaim}
+
+// Run describes an auction run. It is passed to the sinks once before its records.
+type Run struct {
+	ID      string
+	Variant string
+	// Title is used in the header of the text format, e.g. "rollup auction"
+	Title     string
+	Bidders   int
+	StartedAt time.Time
+	// Resumed is set when an aborted run is continued
+	Resumed bool
+}
+
+// Record is the gas measurement of a single transaction.
+type Record struct {
+	RunID             string      `json:"runId"`
+	Variant           string      `json:"variant"`
+	Bidders           int         `json:"bidders"`
+	Phase             Phase       `json:"phase"`
+	Chain             Chain       `json:"chain"`
+	GasUsed           uint64      `json:"gasUsed"`
+	EffectiveGasPrice *big.Int    `json:"effectiveGasPrice"`
+	TxHash            common.Hash `json:"txHash"`
+	Timestamp         time.Time   `json:"timestamp"`
+	// ExecutionMs is the time the node took to accept the transaction. On SUAVE this is the
+	// kettle execution including the HTTP calls of the oracle.
+	ExecutionMs int64 `json:"executionMs,omitempty"`
+	// ConfirmationMs is the time from acceptance until the receipt was available
+	ConfirmationMs int64 `json:"confirmationMs,omitempty"`
+	// PollRounds is the number of lookups until the receipt was available
+	PollRounds int `json:"pollRounds,omitempty"`
+}
+
+// Timing is the wall-clock profile of a transaction, see the fields of Record.
+type Timing struct {
+	Execution    time.Duration
+	Confirmation time.Duration
+	PollRounds   int
+}
+
+// SpanKind is the kind of a Span in the JSONL format, records have no kind.
+const SpanKind = "span"
+
+// Span is the wall-clock time of a step of the driver, e.g. the setup or a single bid.
+type Span struct {
+	Kind       string    `json:"kind"`
+	RunID      string    `json:"runId"`
+	Variant    string    `json:"variant"`
+	Bidders    int       `json:"bidders"`
+	Step       string    `json:"step"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	DurationMs int64     `json:"durationMs"`
+	Failed     bool      `json:"failed,omitempty"`
+}
+
+// Sink is an output format for records.
+type Sink interface {
+	Begin(run Run) error
+	Write(record Record) error
+	Span(span Span) error
+	Close() error
+}
+
+// Recorder turns receipts of a run into records and writes them to all sinks.
+type Recorder struct {
+	mu      sync.Mutex
+	run     Run
+	sinks   []Sink
+	timings map[common.Hash]Timing
+}
+
+// NewRecorder creates a recorder for run and announces the run to the sinks.
+func NewRecorder(run Run, sinks ...Sink) (*Recorder, error) {
+	for _, sink := range sinks {
+		if err := sink.Begin(run); err != nil {
+			return nil, err
+		}
+	}
+	return &Recorder{run: run, sinks: sinks, timings: map[common.Hash]Timing{}}, nil
+}
+
+// Run returns the run the recorder was created for.
+func (r *Recorder) Run() Run {
+	return r.run
+}
+
+// Timing stores the timing of a transaction until its receipt is recorded.
+func (r *Recorder) Timing(txHash common.Hash, timing Timing) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timings[txHash] = timing
+}
+
+// Receipt records the gas used by receipt, together with its timing if one was stored.
+func (r *Recorder) Receipt(phase Phase, chain Chain, receipt *types.Receipt) error {
+	r.mu.Lock()
+	timing := r.timings[receipt.TxHash]
+	delete(r.timings, receipt.TxHash)
+	r.mu.Unlock()
+	return r.Write(Record{
+		RunID:             r.run.ID,
+		Variant:           r.run.Variant,
+		Bidders:           r.run.Bidders,
+		Phase:             phase,
+		Chain:             chain,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: receipt.EffectiveGasPrice,
+		TxHash:            receipt.TxHash,
+		Timestamp:         time.Now().UTC(),
+		ExecutionMs:       timing.Execution.Milliseconds(),
+		ConfirmationMs:    timing.Confirmation.Milliseconds(),
+		PollRounds:        timing.PollRounds,
+	})
+}
+
+// Span records the wall-clock time of a step.
+func (r *Recorder) Span(step string, start, end time.Time, failed bool) error {
+	span := Span{
+		Kind:       SpanKind,
+		RunID:      r.run.ID,
+		Variant:    r.run.Variant,
+		Bidders:    r.run.Bidders,
+		Step:       step,
+		Start:      start.UTC(),
+		End:        end.UTC(),
+		DurationMs: end.Sub(start).Milliseconds(),
+		Failed:     failed,
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sink := range r.sinks {
+		if err := sink.Span(span); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write passes record to all sinks.
+func (r *Recorder) Write(record Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sink := range r.sinks {
+		if err := sink.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes all sinks.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var errs []error
+	for _, sink := range r.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// NewRunID returns a random identifier for a run.
+func NewRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,256 @@
+package metrics
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ReadFile reads the records written by any of the sinks. The format is chosen by the extension
+// like in Open. variantOf maps the run titles of the text format to variant names, it may be nil.
+func ReadFile(path string, variantOf func(title string) string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var records []Record
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = ReadCSV(file)
+	case ".txt":
+		records, err = ReadText(file, variantOf)
+	default:
+		records, err = ReadJSONL(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// ReadJSONL reads the records written by a JSONLSink, spans are skipped.
+func ReadJSONL(r io.Reader) ([]Record, error) {
+	var records []Record
+	err := decodeJSONL(r, func(kind string, raw json.RawMessage) error {
+		if kind != "" {
+			return nil
+		}
+		var record Record
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
+
+// ReadSpans reads the spans written by a JSONLSink.
+func ReadSpans(r io.Reader) ([]Span, error) {
+	var spans []Span
+	err := decodeJSONL(r, func(kind string, raw json.RawMessage) error {
+		if kind != SpanKind {
+			return nil
+		}
+		var span Span
+		if err := json.Unmarshal(raw, &span); err != nil {
+			return err
+		}
+		spans = append(spans, span)
+		return nil
+	})
+	return spans, err
+}
+
+// decodeJSONL calls fn with the kind of every line
+func decodeJSONL(r io.Reader, fn func(kind string, raw json.RawMessage) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var kind struct {
+			Kind string `json:"kind"`
+		}
+		if err := json.Unmarshal(raw, &kind); err != nil {
+			return err
+		}
+		if err := fn(kind.Kind, raw); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadCSV reads the output of a CSVSink. The columns are matched by the header.
+func ReadCSV(r io.Reader) ([]Record, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	column := map[string]int{}
+	for i, name := range rows[0] {
+		column[name] = i
+	}
+	for _, name := range csvRequiredColumns {
+		if _, ok := column[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+	records := make([]Record, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		field := func(name string) string {
+			i, ok := column[name]
+			if !ok {
+				return ""
+			}
+			return row[i]
+		}
+		record := Record{
+			RunID:   field("run_id"),
+			Variant: field("variant"),
+			Phase:   Phase(field("phase")),
+			Chain:   Chain(field("chain")),
+			TxHash:  common.HexToHash(field("tx_hash")),
+		}
+		if record.Bidders, err = strconv.Atoi(field("bidders")); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		if record.GasUsed, err = strconv.ParseUint(field("gas_used"), 10, 64); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		if gasPrice := field("effective_gas_price"); gasPrice != "" {
+			var ok bool
+			if record.EffectiveGasPrice, ok = new(big.Int).SetString(gasPrice, 10); !ok {
+				return nil, fmt.Errorf("row %d: invalid gas price %q", i+2, gasPrice)
+			}
+		}
+		if record.Timestamp, err = time.Parse(time.RFC3339, field("timestamp")); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		if v := field("execution_ms"); v != "" {
+			if record.ExecutionMs, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("row %d: %w", i+2, err)
+			}
+		}
+		if v := field("confirmation_ms"); v != "" {
+			if record.ConfirmationMs, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("row %d: %w", i+2, err)
+			}
+		}
+		if v := field("poll_rounds"); v != "" {
+			if record.PollRounds, err = strconv.Atoi(v); err != nil {
+				return nil, fmt.Errorf("row %d: %w", i+2, err)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+type textKey struct {
+	phase Phase
+	chain Chain
+}
+
+var textHeader = regexp.MustCompile(`^Starting the (.+) with bidder amount: (\d+)$`)
+
+// ReadText parses the free-text format of measurements.txt. The format has no run IDs, so every
+// run gets the ID "text-<n>" in the order of the file. Transaction hashes and gas prices are not
+// part of the format.
+func ReadText(r io.Reader, variantOf func(title string) string) ([]Record, error) {
+	labels := map[string]textKey{}
+	for _, phase := range Phases {
+		for _, chain := range []Chain{ChainSuave, ChainL1} {
+			label := TextLabel(phase, chain)
+			if _, ok := labels[label]; !ok {
+				labels[label] = textKey{phase, chain}
+			}
+		}
+	}
+	// the label of the NFT move does not name the chain, it is only sent on L1
+	labels[TextLabel(PhaseMoveNFT, ChainL1)] = textKey{PhaseMoveNFT, ChainL1}
+
+	var records []Record
+	var run *Run
+	runs := 0
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.Trim(line, "-") == "" {
+			continue
+		}
+		if match := textHeader.FindStringSubmatch(line); match != nil {
+			runs++
+			bidders, _ := strconv.Atoi(match[2])
+			variant := match[1]
+			if variantOf != nil {
+				variant = variantOf(match[1])
+			}
+			run = &Run{ID: fmt.Sprintf("text-%d", runs), Variant: variant, Title: match[1], Bidders: bidders}
+			continue
+		}
+		label, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: unexpected %q", lineNumber, line)
+		}
+		key, ok := labels[label]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown measurement %q", lineNumber, label)
+		}
+		if run == nil {
+			return nil, fmt.Errorf("line %d: measurement before the first run header", lineNumber)
+		}
+		gasUsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		records = append(records, Record{
+			RunID:   run.ID,
+			Variant: run.Variant,
+			Bidders: run.Bidders,
+			Phase:   key.phase,
+			Chain:   key.chain,
+			GasUsed: gasUsed,
+		})
+	}
+	return records, scanner.Err()
+}
+
+// ReadSpansFile reads the spans of a JSONL file. The CSV and text formats have no spans.
+func ReadSpansFile(path string) ([]Span, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".txt":
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	spans, err := ReadSpans(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return spans, nil
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestTextLabel(t *testing.T) {
+	for _, tc := range []struct {
+		phase Phase
+		chain Chain
+		label string
+	}{
+		{PhaseDeploy, ChainSuave, "Deploying the auction contract"},
+		{PhaseMoveNFT, ChainL1, "L1 Moving the NFT to NFT holding address"},
+		{PhaseEnd, ChainSuave, "Ending auction on SUAVE"},
+		{PhaseEnd, ChainL1, "End auction transfer tax on L1"},
+		{PhaseClaim, ChainSuave, "Claiming valuables on SUAVE"},
+		{PhaseClaim, ChainL1, "Claiming valuables on L1"},
+		{Phase("other"), ChainL1, "other on L1"},
+	} {
+		if label := TextLabel(tc.phase, tc.chain); label != tc.label {
+			t.Errorf("TextLabel(%s, %s) = %q, expected %q", tc.phase, tc.chain, label, tc.label)
+		}
+	}
+}
+
+func TestReadTextRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewTextSink(nopCloser{&buf})
+	runs := []struct {
+		run     Run
+		records []Record
+	}{
+		{
+			run: Run{Title: "base auction", Bidders: 2},
+			records: []Record{
+				{Phase: PhaseDeploy, Chain: ChainSuave, GasUsed: 3000000},
+				{Phase: PhaseMoveNFT, Chain: ChainL1, GasUsed: 52000},
+				{Phase: PhaseEnd, Chain: ChainSuave, GasUsed: 410000},
+				{Phase: PhaseEnd, Chain: ChainL1, GasUsed: 21000},
+			},
+		},
+		{
+			run: Run{Title: "proposer auction", Bidders: 1},
+			records: []Record{
+				{Phase: PhaseRefute, Chain: ChainSuave, GasUsed: 120000},
+				{Phase: PhaseClaim, Chain: ChainL1, GasUsed: 21000},
+			},
+		},
+	}
+	var expected []Record
+	for i, r := range runs {
+		if err := sink.Begin(r.run); err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range r.records {
+			if err := sink.Write(record); err != nil {
+				t.Fatal(err)
+			}
+			record.RunID = []string{"text-1", "text-2"}[i]
+			record.Variant = strings.Fields(r.run.Title)[0]
+			record.Bidders = r.run.Bidders
+			expected = append(expected, record)
+		}
+	}
+
+	records, err := ReadText(&buf, func(title string) string { return strings.Fields(title)[0] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("read %+v, expected %+v", records, expected)
+	}
+}
+
+func TestReadTextErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"unknown label", "Starting the base auction with bidder amount: 1\nSomething else: 1\n", "unknown measurement"},
+		{"no run header", "Setup: 1\n", "before the first run header"},
+		{"no gas value", "Starting the base auction with bidder amount: 1\nSetup: many\n", "line 2"},
+		{"no label", "Starting the base auction with bidder amount: 1\nSetup\n", "unexpected"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadText(strings.NewReader(tc.input), nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("error %v, expected %q", err, tc.err)
+			}
+		})
+	}
+}
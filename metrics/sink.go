@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Open creates a sink appending to path. The format is chosen by the extension: .csv for CSV,
+// .txt for the legacy text format and JSONL otherwise.
+func Open(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return NewCSVSink(file, info.Size() == 0), nil
+	case ".txt":
+		return NewTextSink(file), nil
+	default:
+		return NewJSONLSink(file), nil
+	}
+}
+
+// JSONLSink writes one JSON object per record and line.
+type JSONLSink struct {
+	w   io.WriteCloser
+	enc *json.Encoder
+}
+
+func NewJSONLSink(w io.WriteCloser) *JSONLSink {
+	return &JSONLSink{w: w, enc: json.NewEncoder(w)}
+}
+
+func (s *JSONLSink) Begin(run Run) error { return nil }
+
+func (s *JSONLSink) Write(record Record) error {
+	return s.enc.Encode(record)
+}
+
+func (s *JSONLSink) Span(span Span) error {
+	return s.enc.Encode(span)
+}
+
+func (s *JSONLSink) Close() error {
+	return s.w.Close()
+}
+
+// CSVColumns is the header of the CSV format.
+var CSVColumns = []string{"run_id", "variant", "bidders", "phase", "chain", "gas_used", "effective_gas_price", "tx_hash", "timestamp", "execution_ms", "confirmation_ms", "poll_rounds"}
+
+// csvRequiredColumns were written by every version of the CSV format
+var csvRequiredColumns = CSVColumns[:9]
+
+// CSVSink writes the records as CSV with the columns in CSVColumns. Spans are not written.
+type CSVSink struct {
+	w          io.WriteCloser
+	csv        *csv.Writer
+	needHeader bool
+}
+
+// NewCSVSink creates a CSV sink. The header is written before the first record if header is set.
+func NewCSVSink(w io.WriteCloser, header bool) *CSVSink {
+	return &CSVSink{w: w, csv: csv.NewWriter(w), needHeader: header}
+}
+
+func (s *CSVSink) Begin(run Run) error { return nil }
+
+func (s *CSVSink) Write(record Record) error {
+	if s.needHeader {
+		if err := s.csv.Write(CSVColumns); err != nil {
+			return err
+		}
+		s.needHeader = false
+	}
+	gasPrice := ""
+	if record.EffectiveGasPrice != nil {
+		gasPrice = record.EffectiveGasPrice.String()
+	}
+	err := s.csv.Write([]string{
+		record.RunID,
+		record.Variant,
+		strconv.Itoa(record.Bidders),
+		string(record.Phase),
+		string(record.Chain),
+		strconv.FormatUint(record.GasUsed, 10),
+		gasPrice,
+		record.TxHash.Hex(),
+		record.Timestamp.Format(time.RFC3339),
+		strconv.FormatInt(record.ExecutionMs, 10),
+		strconv.FormatInt(record.ConfirmationMs, 10),
+		strconv.Itoa(record.PollRounds),
+	})
+	if err != nil {
+		return err
+	}
+	s.csv.Flush()
+	return s.csv.Error()
+}
+
+func (s *CSVSink) Span(span Span) error { return nil }
+
+func (s *CSVSink) Close() error {
+	s.csv.Flush()
+	if err := s.csv.Error(); err != nil {
+		s.w.Close()
+		return err
+	}
+	return s.w.Close()
+}
+
+// TextSink renders the records in the free-text format of measurements.txt. Timings are not part
+// of the format.
+type TextSink struct {
+	w io.WriteCloser
+}
+
+func NewTextSink(w io.WriteCloser) *TextSink {
+	return &TextSink{w: w}
+}
+
+func (s *TextSink) Begin(run Run) error {
+	if run.Resumed {
+		return nil
+	}
+	_, err := fmt.Fprintf(s.w, "\nStarting the %s with bidder amount: %d\n", run.Title, run.Bidders)
+	return err
+}
+
+func (s *TextSink) Write(record Record) error {
+	_, err := fmt.Fprintf(s.w, "%s: %d\n", TextLabel(record.Phase, record.Chain), record.GasUsed)
+	return err
+}
+
+func (s *TextSink) Span(span Span) error { return nil }
+
+func (s *TextSink) Close() error {
+	return s.w.Close()
+}
+
+// TextLabel is the label of a measurement in measurements.txt.
+func TextLabel(phase Phase, chain Chain) string {
+	switch {
+	case phase == PhaseDeploy:
+		return "Deploying the auction contract"
+	case phase == PhaseSetUp:
+		return "Setup"
+	case phase == PhaseMoveNFT:
+		return "L1 Moving the NFT to NFT holding address"
+	case phase == PhaseStart:
+		return "Start auction"
+	case phase == PhaseBiddingAddress:
+		return "Getting a bidding address"
+	case phase == PhaseEnd && chain == ChainL1:
+		return "End auction transfer tax on L1"
+	case phase == PhaseEnd:
+		return "Ending auction on SUAVE"
+	case phase == PhaseRefute:
+		return "Registering new winner"
+	case phase == PhaseClaim && chain == ChainL1:
+		return "Claiming valuables on L1"
+	case phase == PhaseClaim:
+		return "Claiming valuables on SUAVE"
+	}
+	return fmt.Sprintf("%s on %s", phase, chain)
+}
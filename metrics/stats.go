@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary describes a sample of gas values.
+type Summary struct {
+	N      int     `json:"n"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	// StdDev is the sample standard deviation, 0 for a single value
+	StdDev float64 `json:"stddev"`
+	Min    uint64  `json:"min"`
+	Max    uint64  `json:"max"`
+}
+
+// Summarize computes the summary of values. The zero Summary is returned for no values.
+func Summarize(values []uint64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	sorted := append([]uint64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	s := Summary{N: len(sorted), Min: sorted[0], Max: sorted[len(sorted)-1]}
+	sum := 0.0
+	for _, v := range sorted {
+		sum += float64(v)
+	}
+	s.Mean = sum / float64(s.N)
+	if s.N%2 == 1 {
+		s.Median = float64(sorted[s.N/2])
+	} else {
+		s.Median = (float64(sorted[s.N/2-1]) + float64(sorted[s.N/2])) / 2
+	}
+	if s.N > 1 {
+		squares := 0.0
+		for _, v := range sorted {
+			d := float64(v) - s.Mean
+			squares += d * d
+		}
+		s.StdDev = math.Sqrt(squares / float64(s.N-1))
+	}
+	return s
+}
+
+// Fit is a least squares fit of gas = Fixed + PerBidder * bidders.
+type Fit struct {
+	N         int     `json:"n"`
+	Fixed     float64 `json:"fixed"`
+	PerBidder float64 `json:"perBidder"`
+	// R2 is the coefficient of determination, 1 if the fit explains all variance
+	R2 float64 `json:"r2"`
+}
+
+// FitLinear fits gas against the bidder counts. It reports false if there are less than two
+// distinct bidder counts.
+func FitLinear(bidders []int, gas []uint64) (Fit, bool) {
+	n := len(bidders)
+	if n != len(gas) || n == 0 {
+		return Fit{}, false
+	}
+	var sumX, sumY float64
+	for i := range bidders {
+		sumX += float64(bidders[i])
+		sumY += float64(gas[i])
+	}
+	meanX, meanY := sumX/float64(n), sumY/float64(n)
+	var sxx, sxy, syy float64
+	for i := range bidders {
+		dx, dy := float64(bidders[i])-meanX, float64(gas[i])-meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return Fit{}, false
+	}
+	fit := Fit{N: n, PerBidder: sxy / sxx}
+	fit.Fixed = meanY - fit.PerBidder*meanX
+	fit.R2 = 1
+	if syy > 0 {
+		fit.R2 = sxy * sxy / (sxx * syy)
+	}
+	return fit, true
+}
+
+// GroupKey identifies the records of one phase on one chain for a variant and bidder count.
+type GroupKey struct {
+	Variant string `json:"variant"`
+	Phase   Phase  `json:"phase"`
+	Chain   Chain  `json:"chain"`
+	Bidders int    `json:"bidders"`
+}
+
+// GroupByTx groups the gas of the individual transactions.
+func GroupByTx(records []Record) map[GroupKey][]uint64 {
+	groups := map[GroupKey][]uint64{}
+	for _, r := range records {
+		key := GroupKey{Variant: r.Variant, Phase: r.Phase, Chain: r.Chain, Bidders: r.Bidders}
+		groups[key] = append(groups[key], r.GasUsed)
+	}
+	return groups
+}
+
+// GroupByRun sums the gas of each phase and chain per run, e.g. the bidding addresses of all
+// bidders. These totals are what grows with the bidder count.
+func GroupByRun(records []Record) map[GroupKey][]uint64 {
+	type runKey struct {
+		GroupKey
+		runID string
+	}
+	totals := map[runKey]uint64{}
+	var order []runKey
+	for _, r := range records {
+		key := runKey{GroupKey{Variant: r.Variant, Phase: r.Phase, Chain: r.Chain, Bidders: r.Bidders}, r.RunID}
+		if _, ok := totals[key]; !ok {
+			order = append(order, key)
+		}
+		totals[key] += r.GasUsed
+	}
+	groups := map[GroupKey][]uint64{}
+	for _, key := range order {
+		groups[key.GroupKey] = append(groups[key.GroupKey], totals[key])
+	}
+	return groups
+}
+
+// SortGroupKeys orders keys by variant, phase order, chain and bidder count.
+func SortGroupKeys(keys []GroupKey) {
+	phaseIndex := map[Phase]int{}
+	for i, phase := range Phases {
+		phaseIndex[phase] = i
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch {
+		case a.Variant != b.Variant:
+			return a.Variant < b.Variant
+		case a.Phase != b.Phase:
+			return phaseIndex[a.Phase] < phaseIndex[b.Phase]
+		case a.Chain != b.Chain:
+			return a.Chain > b.Chain
+		default:
+			return a.Bidders < b.Bidders
+		}
+	})
+}
+
+// LatencyBuckets are the upper bounds in milliseconds of the latency histogram buckets.
+var LatencyBuckets = []uint64{100, 250, 500, 1000, 2500, 5000, 10000, 25000, 50000, 100000, 250000}
+
+// Histogram counts values per bucket. Counts has one more entry than Bounds for the values above
+// the last bound.
+type Histogram struct {
+	Bounds []uint64 `json:"bounds"`
+	Counts []int    `json:"counts"`
+}
+
+// NewHistogram sorts values into the buckets bounded by bounds, a value equal to a bound belongs
+// to that bucket.
+func NewHistogram(bounds []uint64, values []uint64) Histogram {
+	h := Histogram{Bounds: bounds, Counts: make([]int, len(bounds)+1)}
+	for _, v := range values {
+		h.Counts[sort.Search(len(bounds), func(i int) bool { return v <= bounds[i] })]++
+	}
+	return h
+}
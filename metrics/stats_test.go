@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		values []uint64
+		want   Summary
+	}{
+		{
+			name: "no values",
+		},
+		{
+			name:   "single value",
+			values: []uint64{7},
+			want:   Summary{N: 1, Mean: 7, Median: 7, Min: 7, Max: 7},
+		},
+		{
+			name:   "odd count takes the middle value",
+			values: []uint64{5, 1, 3},
+			// squares 4+0+4 divided by N-1
+			want: Summary{N: 3, Mean: 3, Median: 3, StdDev: 2, Min: 1, Max: 5},
+		},
+		{
+			name:   "even count averages the middle values",
+			values: []uint64{4, 1, 3, 2},
+			// squares 2.25+0.25+0.25+2.25 divided by N-1
+			want: Summary{N: 4, Mean: 2.5, Median: 2.5, StdDev: math.Sqrt(5.0 / 3), Min: 1, Max: 4},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Summarize(tc.values)
+			if math.Abs(got.StdDev-tc.want.StdDev) > 1e-9 {
+				t.Fatalf("stddev %v, expected %v", got.StdDev, tc.want.StdDev)
+			}
+			got.StdDev = tc.want.StdDev
+			if got != tc.want {
+				t.Fatalf("got %+v, expected %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeKeepsValues(t *testing.T) {
+	values := []uint64{3, 1, 2}
+	Summarize(values)
+	if !reflect.DeepEqual(values, []uint64{3, 1, 2}) {
+		t.Fatalf("values sorted in place: %v", values)
+	}
+}
+
+func TestFitLinear(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		bidders []int
+		gas     []uint64
+		want    Fit
+		ok      bool
+	}{
+		{
+			name:    "exact line",
+			bidders: []int{1, 2, 3},
+			gas:     []uint64{110, 120, 130},
+			want:    Fit{N: 3, Fixed: 100, PerBidder: 10, R2: 1},
+			ok:      true,
+		},
+		{
+			name:    "noise lowers R2",
+			bidders: []int{1, 2, 3},
+			gas:     []uint64{10, 30, 20},
+			// sxy 10, sxx 2, syy 200
+			want: Fit{N: 3, Fixed: 10, PerBidder: 5, R2: 0.25},
+			ok:   true,
+		},
+		{
+			name:    "constant gas",
+			bidders: []int{1, 2},
+			gas:     []uint64{5, 5},
+			want:    Fit{N: 2, Fixed: 5, PerBidder: 0, R2: 1},
+			ok:      true,
+		},
+		{
+			name:    "single bidder count",
+			bidders: []int{2, 2},
+			gas:     []uint64{10, 20},
+		},
+		{
+			name:    "mismatched lengths",
+			bidders: []int{1, 2},
+			gas:     []uint64{10},
+		},
+		{
+			name: "no values",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := FitLinear(tc.bidders, tc.gas)
+			if ok != tc.ok {
+				t.Fatalf("ok %v, expected %v", ok, tc.ok)
+			}
+			if got.N != tc.want.N || math.Abs(got.Fixed-tc.want.Fixed) > 1e-9 ||
+				math.Abs(got.PerBidder-tc.want.PerBidder) > 1e-9 || math.Abs(got.R2-tc.want.R2) > 1e-9 {
+				t.Fatalf("got %+v, expected %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupByRun(t *testing.T) {
+	records := []Record{
+		{RunID: "a", Variant: "proposer", Bidders: 2, Phase: PhaseBiddingAddress, Chain: ChainSuave, GasUsed: 100},
+		{RunID: "a", Variant: "proposer", Bidders: 2, Phase: PhaseEnd, Chain: ChainSuave, GasUsed: 50},
+		{RunID: "a", Variant: "proposer", Bidders: 2, Phase: PhaseBiddingAddress, Chain: ChainSuave, GasUsed: 200},
+		{RunID: "b", Variant: "proposer", Bidders: 2, Phase: PhaseBiddingAddress, Chain: ChainSuave, GasUsed: 150},
+		{RunID: "b", Variant: "proposer", Bidders: 2, Phase: PhaseBiddingAddress, Chain: ChainSuave, GasUsed: 160},
+		{RunID: "b", Variant: "proposer", Bidders: 2, Phase: PhaseEnd, Chain: ChainL1, GasUsed: 21000},
+	}
+	want := map[GroupKey][]uint64{
+		{Variant: "proposer", Phase: PhaseBiddingAddress, Chain: ChainSuave, Bidders: 2}: {300, 310},
+		{Variant: "proposer", Phase: PhaseEnd, Chain: ChainSuave, Bidders: 2}:            {50},
+		{Variant: "proposer", Phase: PhaseEnd, Chain: ChainL1, Bidders: 2}:               {21000},
+	}
+	if got := GroupByRun(records); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, expected %v", got, want)
+	}
+}
@@ -0,0 +1,240 @@
+// Package proof exports the outcome of an ended auction as a bundle of eth_getProof account proofs
+// for the revealed bidding addresses, which anyone can verify offline against the state root of
+// the L1 block the oracle looked up. The bundle does not prove that its addresses are all revealed
+// addresses of the auction: the list is taken from the SUAVE contract by the exporter and could
+// leave out an address with a higher balance.
+package proof
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"suave/sealedauction/auction"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// AccountProof is the eth_getProof response for a bidding address, without storage proofs.
+type AccountProof struct {
+	Address      common.Address  `json:"address"`
+	Balance      *hexutil.Big    `json:"balance"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+}
+
+// Bundle is the exported outcome of an auction. Outcome holds the registered winner and the
+// balances the winner is recomputed from, the account proofs back every balance with the state
+// root of Header. Next is the header of the following block, it proves that Header is the last
+// block at or before the end time. The list of addresses in Outcome is not proven, it is only as
+// complete as the revealedL1Addresses the exporter read.
+type Bundle struct {
+	ChainID  uint64                `json:"chainId"`
+	Auction  common.Address        `json:"auction"`
+	Outcome  *auction.Verification `json:"outcome"`
+	Header   hexutil.Bytes         `json:"header"`
+	Next     hexutil.Bytes         `json:"next"`
+	Accounts []AccountProof        `json:"accounts"`
+}
+
+// Result is the outcome of verifying a bundle. BlockHash is only as trustworthy as its source,
+// compare it with a block explorer or a node you trust.
+type Result struct {
+	BlockHash common.Hash
+	Block     uint64
+	StateRoot common.Hash
+	// Verification is the outcome recomputed from the proven balances
+	Verification *auction.Verification
+}
+
+// Export fetches the account proofs of the revealed bidding addresses of v at v.Block, verifies
+// them against the headers and returns the bundle. v must be the result of auction.Client.Verify,
+// the bundle is exported whether or not the registered outcome matches the balances.
+func Export(ctx context.Context, client *ethclient.Client, chainID *big.Int, auctionAddress common.Address, v *auction.Verification) (*Bundle, error) {
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(v.Block))
+	if err != nil {
+		return nil, fmt.Errorf("header of block %d: %w", v.Block, err)
+	}
+	next, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(v.Block+1))
+	if err != nil {
+		return nil, fmt.Errorf("header of block %d, wait for the block after the end of the auction: %w", v.Block+1, err)
+	}
+	b := &Bundle{ChainID: chainID.Uint64(), Auction: auctionAddress, Outcome: v}
+	if b.Header, err = rlp.EncodeToBytes(header); err != nil {
+		return nil, err
+	}
+	if b.Next, err = rlp.EncodeToBytes(next); err != nil {
+		return nil, err
+	}
+	block := hexutil.EncodeUint64(v.Block)
+	for _, balance := range v.Balances {
+		var account AccountProof
+		if err := client.Client().CallContext(ctx, &account, "eth_getProof", balance.Address, []string{}, block); err != nil {
+			return nil, fmt.Errorf("eth_getProof of %s at block %d: %w", balance.Address.Hex(), v.Block, err)
+		}
+		b.Accounts = append(b.Accounts, account)
+	}
+	// the node should never serve proofs that do not match its own header. A registered outcome
+	// that does not match the balances is exported all the same, that is what the bundle proves.
+	if _, _, err := b.verifyProofs(); err != nil {
+		return nil, fmt.Errorf("verifying the exported proofs: %w", err)
+	}
+	return b, nil
+}
+
+// Verify checks the bundle without network access. The headers must chain and enclose the end
+// time, every account proof must match the state root and the balances they prove must yield the
+// registered winner and winning bid. Verify does not prove that the bundle lists every revealed
+// bidding address, compare them with the revealedL1Addresses of the auction on SUAVE.
+func (b *Bundle) Verify() (*Result, error) {
+	res, proven, err := b.verifyProofs()
+	if err != nil {
+		return nil, err
+	}
+
+	// recompute the outcome from the proven balances only, in the order they were revealed
+	v := *b.Outcome
+	v.Balances = make([]auction.BidderBalance, len(b.Outcome.Balances))
+	for i, balance := range b.Outcome.Balances {
+		amount, ok := proven[balance.Address]
+		if !ok {
+			return nil, fmt.Errorf("no account proof for the bidding address %s", balance.Address.Hex())
+		}
+		v.Balances[i] = auction.BidderBalance{Address: balance.Address, Balance: amount}
+	}
+	v.Evaluate()
+	res.Verification = &v
+	if !v.OK() {
+		return res, fmt.Errorf("the registered winner %s with bid %s does not match the proven balances, expected %s with bid %s",
+			v.Registered.WinnerL1.Hex(), v.Registered.WinningBid, v.Expected.WinnerL1.Hex(), v.Expected.WinningBid)
+	}
+	return res, nil
+}
+
+// verifyProofs checks that the headers chain and enclose the end time and that every account
+// proof matches the state root. It returns the proven balances by address.
+func (b *Bundle) verifyProofs() (*Result, map[common.Address]*big.Int, error) {
+	if b.Outcome == nil || b.Outcome.Registered == nil {
+		return nil, nil, errors.New("the bundle has no registered outcome")
+	}
+	var header, next types.Header
+	if err := rlp.DecodeBytes(b.Header, &header); err != nil {
+		return nil, nil, fmt.Errorf("decoding the header: %w", err)
+	}
+	if err := rlp.DecodeBytes(b.Next, &next); err != nil {
+		return nil, nil, fmt.Errorf("decoding the next header: %w", err)
+	}
+	res := &Result{BlockHash: header.Hash(), Block: header.Number.Uint64(), StateRoot: header.Root}
+	if res.Block != b.Outcome.Block {
+		return nil, nil, fmt.Errorf("the header is block %d, the balances are of block %d", res.Block, b.Outcome.Block)
+	}
+	if header.Time > b.Outcome.EndTime {
+		return nil, nil, fmt.Errorf("block %d at %d is after the end of the auction at %d", res.Block, header.Time, b.Outcome.EndTime)
+	}
+	if next.ParentHash != res.BlockHash || next.Number.Uint64() != res.Block+1 {
+		return nil, nil, fmt.Errorf("the next header is not the child of block %d", res.Block)
+	}
+	if next.Time <= b.Outcome.EndTime {
+		return nil, nil, fmt.Errorf("block %d at %d is not after the end of the auction at %d, block %d is not the last one before it", res.Block+1, next.Time, b.Outcome.EndTime, res.Block)
+	}
+
+	proven := make(map[common.Address]*big.Int, len(b.Accounts))
+	for _, account := range b.Accounts {
+		balance, err := verifyAccount(header.Root, account)
+		if err != nil {
+			return nil, nil, fmt.Errorf("account %s: %w", account.Address.Hex(), err)
+		}
+		proven[account.Address] = balance
+	}
+	return res, proven, nil
+}
+
+// stateAccount is the RLP encoding of an account in the state trie
+type stateAccount struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+// verifyAccount checks the account proof against root and returns the proven balance. An address
+// that was never funded is proven absent and has a balance of zero.
+func verifyAccount(root common.Hash, account AccountProof) (*big.Int, error) {
+	db := make(proofDB, len(account.AccountProof))
+	for _, node := range account.AccountProof {
+		db[string(crypto.Keccak256(node))] = node
+	}
+	value, err := trie.VerifyProof(root, crypto.Keccak256(account.Address.Bytes()), db)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	claimed := new(big.Int)
+	if account.Balance != nil {
+		claimed = account.Balance.ToInt()
+	}
+	if len(value) == 0 {
+		if claimed.Sign() != 0 {
+			return nil, fmt.Errorf("the proof shows no account but the balance is %s", claimed)
+		}
+		return new(big.Int), nil
+	}
+	var state stateAccount
+	if err := rlp.DecodeBytes(value, &state); err != nil {
+		return nil, fmt.Errorf("decoding the account: %w", err)
+	}
+	if state.Balance.Cmp(claimed) != 0 || state.Nonce != uint64(account.Nonce) ||
+		state.Root != account.StorageHash || !bytes.Equal(state.CodeHash, account.CodeHash.Bytes()) {
+		return nil, errors.New("the proven account does not match the returned fields")
+	}
+	return state.Balance, nil
+}
+
+// proofDB serves the trie nodes of a proof by their hash
+type proofDB map[string][]byte
+
+func (db proofDB) Has(key []byte) (bool, error) {
+	_, ok := db[string(key)]
+	return ok, nil
+}
+
+func (db proofDB) Get(key []byte) ([]byte, error) {
+	node, ok := db[string(key)]
+	if !ok {
+		return nil, errors.New("missing trie node")
+	}
+	return node, nil
+}
+
+// Write stores the bundle as indented JSON.
+func (b *Bundle) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Read loads a bundle written by Write.
+func Read(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return &b, nil
+}
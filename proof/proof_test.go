@@ -0,0 +1,87 @@
+package proof
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// singleAccountProof builds a state trie holding only the account of address and returns its root
+// and the proof of the account.
+func singleAccountProof(t *testing.T, address common.Address, balance int64, nonce uint64) (common.Hash, AccountProof) {
+	t.Helper()
+	emptyRoot := common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+	emptyCode := crypto.Keccak256(nil)
+	value, err := rlp.EncodeToBytes(stateAccount{Nonce: nonce, Balance: big.NewInt(balance), Root: emptyRoot, CodeHash: emptyCode})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a leaf with the full key path, 0x20 marks a leaf with an even number of nibbles
+	path := append([]byte{0x20}, crypto.Keccak256(address.Bytes())...)
+	leaf, err := rlp.EncodeToBytes([][]byte{path, value})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.Keccak256Hash(leaf), AccountProof{
+		Address:      address,
+		Balance:      (*hexutil.Big)(big.NewInt(balance)),
+		Nonce:        hexutil.Uint64(nonce),
+		StorageHash:  emptyRoot,
+		CodeHash:     common.BytesToHash(emptyCode),
+		AccountProof: []hexutil.Bytes{leaf},
+	}
+}
+
+func TestVerifyAccount(t *testing.T) {
+	bidder := common.Address{0x01}
+	root, account := singleAccountProof(t, bidder, 1000, 3)
+
+	tampered := account
+	tampered.Balance = (*hexutil.Big)(big.NewInt(2000))
+
+	wrongNonce := account
+	wrongNonce.Nonce = 4
+
+	// the leaf of bidder proves that other has no account
+	absent := account
+	absent.Address = common.Address{0x02}
+	absent.Balance = (*hexutil.Big)(new(big.Int))
+	absentFunded := absent
+	absentFunded.Balance = (*hexutil.Big)(big.NewInt(1))
+
+	for _, tc := range []struct {
+		name    string
+		root    common.Hash
+		account AccountProof
+		balance int64
+		err     string
+	}{
+		{name: "valid", root: root, account: account, balance: 1000},
+		{name: "tampered balance", root: root, account: tampered, err: "does not match"},
+		{name: "tampered nonce", root: root, account: wrongNonce, err: "does not match"},
+		{name: "wrong root", root: common.Hash{0xff}, account: account, err: "invalid proof"},
+		{name: "absent account", root: root, account: absent, balance: 0},
+		{name: "absent account with a balance", root: root, account: absentFunded, err: "no account"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			balance, err := verifyAccount(tc.root, tc.account)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("error %v, expected %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if balance.Cmp(big.NewInt(tc.balance)) != 0 {
+				t.Fatalf("balance %s, expected %d", balance, tc.balance)
+			}
+		})
+	}
+}
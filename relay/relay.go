@@ -0,0 +1,441 @@
+// Package relay broadcasts the L1 transactions the OracleProposer signs but does not send. It
+// follows the EncodedTx logs of known oracles on SUAVE, validates every transaction against L1,
+// broadcasts it and sends it again whenever it drops out of the mempool before its inclusion.
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"suave/sealedauction/auction"
+	"suave/sealedauction/l1"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Status is the state of a relayed transaction.
+type Status string
+
+const (
+	// StatusQueued: valid but not sendable yet, its nonce is ahead of the sender or the sender
+	// can not pay for it
+	StatusQueued Status = "queued"
+	// StatusPending: broadcast and waiting for inclusion
+	StatusPending Status = "pending"
+	// StatusIncluded: mined on L1
+	StatusIncluded Status = "included"
+	// StatusRejected: failed the validation and is never sent
+	StatusRejected Status = "rejected"
+	// StatusConsumed: another transaction of the sender was included with its nonce
+	StatusConsumed Status = "consumed"
+	// StatusAbandoned: dropped more than MaxBroadcasts times
+	StatusAbandoned Status = "abandoned"
+)
+
+// Final reports whether the relay stops tracking a transaction in this status.
+func (s Status) Final() bool {
+	return s != StatusQueued && s != StatusPending
+}
+
+// Tx is a transaction found in an EncodedTx log.
+type Tx struct {
+	Tx     *types.Transaction
+	From   common.Address
+	Oracle common.Address
+	// Request is the SUAVE transaction that emitted the EncodedTx log
+	Request    common.Hash
+	Status     Status
+	Broadcasts int
+	Receipt    *types.Receipt
+	// Reason explains a queued, rejected, consumed or abandoned transaction
+	Reason string
+
+	// set when the nonce of the sender passed the transaction without a receipt, the receipt
+	// might lag behind the nonce for one round
+	nonceGone bool
+}
+
+// Auction holds what the relay checks the transactions of an auction's oracle against. The oracle
+// signs two kinds of transactions: the NFT transfer calls transferFrom(NFTHolder, recipient,
+// TokenID) on NFTContract without value, and the ETH transfers of the winning bid, refunds and
+// backed out bids send the whole balance of a bidding address, less the fee of 21000 gas, to the
+// return address of the claimer.
+type Auction struct {
+	NFTContract common.Address
+	TokenID     *big.Int
+	NFTHolder   common.Address
+}
+
+// transferFromSelector is the selector of transferFrom(address,address,uint256)
+var transferFromSelector = []byte{0x23, 0xb8, 0x72, 0xdd}
+
+// ethTransferGas is the gas limit of the ETH transfers the oracle signs
+const ethTransferGas = 21000
+
+// Relay follows the EncodedTx logs of a set of oracles. The SUAVE RPC is usually served over HTTP,
+// so the logs are polled with eth_getLogs instead of a websocket subscription.
+type Relay struct {
+	suave   *ethclient.Client
+	l1      *ethclient.Client
+	sender  *l1.Manager
+	chainID *big.Int
+	oracles []common.Address
+	decoder *auction.Decoder
+	topic   common.Hash
+
+	// Auctions maps an oracle to the auction it serves, the transactions of the oracle must be
+	// one of the transfers of the auction
+	Auctions map[common.Address]*Auction
+	// Recipients restricts the accepted recipients of the ETH or the NFT, nil accepts any. Run
+	// requires it for every oracle that is not in Auctions.
+	Recipients map[common.Address]bool
+	// PollInterval is how often SUAVE and L1 are checked, defaults to 5 seconds
+	PollInterval time.Duration
+	// MaxBroadcasts is how often a dropped transaction is sent before it is abandoned
+	MaxBroadcasts int
+	// OnUpdate is called whenever a transaction changes its status
+	OnUpdate func(tx *Tx)
+	// Logf reports the progress, defaults to discarding it
+	Logf func(format string, args ...interface{})
+
+	next uint64
+	txs  map[common.Hash]*Tx
+	// open holds the transactions that are not final, in the order they were found
+	open []*Tx
+}
+
+// maxLogRange is the largest block range of a single eth_getLogs request
+const maxLogRange = 1000
+
+// New creates a relay for the EncodedTx logs of oracles. oracleAbi must declare EncodedTx, chainID
+// is the L1 chain the transactions must be signed for and sender broadcasts them.
+func New(suaveClient, l1Client *ethclient.Client, sender *l1.Manager, chainID *big.Int, oracleAbi *abi.ABI, oracles []common.Address) (*Relay, error) {
+	event, ok := oracleAbi.Events["EncodedTx"]
+	if !ok {
+		return nil, errors.New("the oracle ABI has no EncodedTx event")
+	}
+	if len(oracles) == 0 {
+		return nil, errors.New("no oracle addresses to follow")
+	}
+	return &Relay{
+		suave:         suaveClient,
+		l1:            l1Client,
+		sender:        sender,
+		chainID:       chainID,
+		oracles:       oracles,
+		decoder:       auction.NewDecoder(oracleAbi),
+		topic:         event.ID,
+		PollInterval:  5 * time.Second,
+		MaxBroadcasts: 10,
+		Logf:          func(string, ...interface{}) {},
+		txs:           map[common.Hash]*Tx{},
+	}, nil
+}
+
+// Run relays the transactions of the logs from SUAVE block fromBlock on until ctx is done. With
+// fromBlock 0 it starts at the current block. Failed RPC requests are reported and retried in the
+// next round.
+func (r *Relay) Run(ctx context.Context, fromBlock uint64) error {
+	if r.Recipients == nil {
+		for _, oracle := range r.oracles {
+			if r.Auctions[oracle] == nil {
+				return fmt.Errorf("oracle %s has neither an auction nor accepted recipients to check its transactions against", oracle.Hex())
+			}
+		}
+	}
+	r.next = fromBlock
+	if r.next == 0 {
+		head, err := r.suave.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("reading the SUAVE block number: %w", err)
+		}
+		r.next = head
+	}
+	r.Logf("following EncodedTx of %d oracles from SUAVE block %d", len(r.oracles), r.next)
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.poll(ctx); err != nil && ctx.Err() == nil {
+			r.Logf("reading SUAVE logs: %v", err)
+		}
+		if err := r.track(ctx); err != nil && ctx.Err() == nil {
+			r.Logf("tracking L1 transactions: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads the EncodedTx logs of the new SUAVE blocks
+func (r *Relay) poll(ctx context.Context) error {
+	head, err := r.suave.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	for r.next <= head {
+		to := min(r.next+maxLogRange-1, head)
+		logs, err := r.suave.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(r.next),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: r.oracles,
+			Topics:    [][]common.Hash{{r.topic}},
+		})
+		if err != nil {
+			return err
+		}
+		for i := range logs {
+			event, err := r.decoder.DecodeLog(&logs[i])
+			if err != nil {
+				r.Logf("SUAVE transaction %s: %v", logs[i].TxHash.Hex(), err)
+				continue
+			}
+			if e, ok := event.(*auction.EncodedTx); ok {
+				r.add(e)
+			}
+		}
+		r.next = to + 1
+	}
+	return nil
+}
+
+// add decodes the transaction of an EncodedTx log and checks what does not depend on L1
+func (r *Relay) add(e *auction.EncodedTx) {
+	t := &Tx{Oracle: e.Raw.Address, Request: e.Raw.TxHash, Tx: new(types.Transaction)}
+	reject := func(format string, args ...interface{}) {
+		t.Status = StatusRejected
+		t.Reason = fmt.Sprintf(format, args...)
+		r.Logf("rejecting the transaction of SUAVE transaction %s: %s", t.Request.Hex(), t.Reason)
+		r.update(t)
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(e.SignedTx, "0x"))
+	if err != nil {
+		reject("invalid hex: %v", err)
+		return
+	}
+	if err := t.Tx.UnmarshalBinary(raw); err != nil {
+		reject("invalid transaction: %v", err)
+		return
+	}
+	if _, ok := r.txs[t.Tx.Hash()]; ok {
+		return
+	}
+	r.txs[t.Tx.Hash()] = t
+
+	if !t.Tx.Protected() || t.Tx.ChainId().Cmp(r.chainID) != 0 {
+		reject("%s is signed for chain %s, not %s", t.Tx.Hash().Hex(), t.Tx.ChainId(), r.chainID)
+		return
+	}
+	if t.From, err = types.Sender(types.LatestSignerForChainID(r.chainID), t.Tx); err != nil {
+		reject("%s has an invalid signature: %v", t.Tx.Hash().Hex(), err)
+		return
+	}
+	if t.Tx.To() == nil {
+		reject("%s creates a contract", t.Tx.Hash().Hex())
+		return
+	}
+	recipient := *t.Tx.To()
+	if a := r.Auctions[t.Oracle]; a != nil {
+		if recipient, err = a.recipient(t.From, t.Tx); err != nil {
+			reject("%s is no transfer of the auction: %v", t.Tx.Hash().Hex(), err)
+			return
+		}
+	}
+	if r.Recipients != nil && !r.Recipients[recipient] {
+		reject("%s transfers to %s, which is not an accepted recipient", t.Tx.Hash().Hex(), recipient.Hex())
+		return
+	}
+	r.Logf("found %s from %s to %s with value %s and nonce %d", t.Tx.Hash().Hex(), t.From.Hex(), recipient.Hex(), t.Tx.Value(), t.Tx.Nonce())
+	t.Status = StatusQueued
+	r.open = append(r.open, t)
+}
+
+// recipient checks that tx of from is one of the transfers of the auction and returns the address
+// that receives the NFT or the ETH
+func (a *Auction) recipient(from common.Address, tx *types.Transaction) (common.Address, error) {
+	if from == a.NFTHolder {
+		data := tx.Data()
+		switch {
+		case *tx.To() != a.NFTContract:
+			return common.Address{}, fmt.Errorf("the NFT holding address calls %s, not the NFT contract %s", tx.To().Hex(), a.NFTContract.Hex())
+		case tx.Value().Sign() != 0:
+			return common.Address{}, fmt.Errorf("the NFT transfer has a value of %s", tx.Value())
+		case len(data) != 4+3*32 || !bytes.Equal(data[:4], transferFromSelector):
+			return common.Address{}, errors.New("the NFT holding address does not call transferFrom")
+		case common.BytesToAddress(data[4:36]) != a.NFTHolder:
+			return common.Address{}, fmt.Errorf("transferFrom moves the NFT of %s", common.BytesToAddress(data[4:36]).Hex())
+		case new(big.Int).SetBytes(data[68:100]).Cmp(a.TokenID) != 0:
+			return common.Address{}, fmt.Errorf("transferFrom moves token %s, not %s", new(big.Int).SetBytes(data[68:100]), a.TokenID)
+		}
+		return common.BytesToAddress(data[36:68]), nil
+	}
+	switch {
+	case len(tx.Data()) != 0:
+		return common.Address{}, errors.New("the ETH transfer carries data")
+	case tx.Gas() != ethTransferGas:
+		return common.Address{}, fmt.Errorf("the ETH transfer has a gas limit of %d, not %d", tx.Gas(), ethTransferGas)
+	case tx.Value().Sign() == 0:
+		return common.Address{}, errors.New("the ETH transfer has no value")
+	}
+	return *tx.To(), nil
+}
+
+// track sends the queued transactions that became valid, checks the pending ones for their
+// inclusion and sends the dropped ones again
+func (r *Relay) track(ctx context.Context) error {
+	open := r.open[:0]
+	var firstErr error
+	for _, t := range r.open {
+		var err error
+		switch t.Status {
+		case StatusQueued:
+			err = r.send(ctx, t)
+		case StatusPending:
+			err = r.check(ctx, t)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", t.Tx.Hash().Hex(), err)
+		}
+		if !t.Status.Final() {
+			open = append(open, t)
+		}
+	}
+	r.open = open
+	return firstErr
+}
+
+// send checks the nonce and the balance of the sender on L1 and broadcasts t if both allow it
+func (r *Relay) send(ctx context.Context, t *Tx) error {
+	nonce := t.Tx.Nonce()
+	confirmed, err := r.l1.NonceAt(ctx, t.From, nil)
+	if err != nil {
+		return err
+	}
+	if nonce < confirmed {
+		return r.nonceUsed(ctx, t)
+	}
+	pending, err := r.l1.PendingNonceAt(ctx, t.From)
+	if err != nil {
+		return err
+	}
+	if nonce > pending {
+		r.queue(t, fmt.Sprintf("nonce %d is ahead of the next nonce %d of %s", nonce, pending, t.From.Hex()))
+		return nil
+	}
+	balance, err := r.l1.BalanceAt(ctx, t.From, nil)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(t.Tx.Cost()) < 0 {
+		r.queue(t, fmt.Sprintf("%s holds %s but the transaction costs up to %s", t.From.Hex(), balance, t.Tx.Cost()))
+		return nil
+	}
+	if a := r.Auctions[t.Oracle]; a != nil && t.From != a.NFTHolder && balance.Cmp(t.Tx.Cost()) != 0 {
+		// the oracle transfers the whole balance of a bidding address at the time it signs
+		t.Status = StatusRejected
+		t.Reason = fmt.Sprintf("%s holds %s, the transfer of %s with fees of up to %s does not match the bid", t.From.Hex(), balance, t.Tx.Value(), new(big.Int).Sub(t.Tx.Cost(), t.Tx.Value()))
+		r.Logf("rejecting %s: %s", t.Tx.Hash().Hex(), t.Reason)
+		r.update(t)
+		return nil
+	}
+	return r.broadcast(ctx, t)
+}
+
+// check looks for the receipt of a pending transaction and sends it again if the node dropped it
+func (r *Relay) check(ctx context.Context, t *Tx) error {
+	receipt, err := r.l1.TransactionReceipt(ctx, t.Tx.Hash())
+	if err == nil {
+		t.Status, t.Receipt, t.Reason = StatusIncluded, receipt, ""
+		r.Logf("%s included in L1 block %s with status %d", t.Tx.Hash().Hex(), receipt.BlockNumber, receipt.Status)
+		r.update(t)
+		return nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return err
+	}
+	confirmed, err := r.l1.NonceAt(ctx, t.From, nil)
+	if err != nil {
+		return err
+	}
+	if t.Tx.Nonce() < confirmed {
+		return r.nonceUsed(ctx, t)
+	}
+	_, _, err = r.l1.TransactionByHash(ctx, t.Tx.Hash())
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, ethereum.NotFound):
+		return err
+	case t.Broadcasts > r.MaxBroadcasts:
+		t.Status = StatusAbandoned
+		t.Reason = fmt.Sprintf("dropped after %d broadcasts", t.Broadcasts)
+		r.Logf("abandoning %s: %s", t.Tx.Hash().Hex(), t.Reason)
+		r.update(t)
+		return nil
+	default:
+		r.Logf("%s was dropped, sending it again", t.Tx.Hash().Hex())
+		return r.broadcast(ctx, t)
+	}
+}
+
+// nonceUsed handles a transaction whose nonce is below the confirmed nonce of its sender. Unless
+// its receipt shows up within one more round, another transaction used the nonce.
+func (r *Relay) nonceUsed(ctx context.Context, t *Tx) error {
+	receipt, err := r.l1.TransactionReceipt(ctx, t.Tx.Hash())
+	switch {
+	case err == nil:
+		t.Status, t.Receipt, t.Reason = StatusIncluded, receipt, ""
+		r.Logf("%s included in L1 block %s with status %d", t.Tx.Hash().Hex(), receipt.BlockNumber, receipt.Status)
+	case !errors.Is(err, ethereum.NotFound):
+		return err
+	case !t.nonceGone:
+		t.nonceGone = true
+		return nil
+	default:
+		t.Status = StatusConsumed
+		t.Reason = fmt.Sprintf("nonce %d of %s was used by another transaction", t.Tx.Nonce(), t.From.Hex())
+		r.Logf("giving up %s: %s", t.Tx.Hash().Hex(), t.Reason)
+	}
+	r.update(t)
+	return nil
+}
+
+func (r *Relay) broadcast(ctx context.Context, t *Tx) error {
+	if err := r.sender.SendSigned(ctx, t.Tx); err != nil {
+		return err
+	}
+	t.Broadcasts++
+	t.Status, t.Reason = StatusPending, ""
+	r.Logf("broadcast %s (%d)", t.Tx.Hash().Hex(), t.Broadcasts)
+	r.update(t)
+	return nil
+}
+
+// queue keeps t queued and reports a new reason
+func (r *Relay) queue(t *Tx, reason string) {
+	if t.Reason == reason {
+		return
+	}
+	t.Reason = reason
+	r.Logf("holding %s: %s", t.Tx.Hash().Hex(), reason)
+	r.update(t)
+}
+
+func (r *Relay) update(t *Tx) {
+	if r.OnUpdate != nil {
+		r.OnUpdate(t)
+	}
+}
@@ -0,0 +1,60 @@
+package relay
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestAuctionRecipient(t *testing.T) {
+	a := &Auction{NFTContract: common.Address{0xc0}, TokenID: big.NewInt(7), NFTHolder: common.Address{0xa0}}
+	bidder, winner := common.Address{0xb0}, common.Address{0x01}
+
+	transferFrom := func(from, to common.Address, tokenID int64) []byte {
+		data := append([]byte{}, transferFromSelector...)
+		data = append(data, common.LeftPadBytes(from.Bytes(), 32)...)
+		data = append(data, common.LeftPadBytes(to.Bytes(), 32)...)
+		return append(data, common.LeftPadBytes(big.NewInt(tokenID).Bytes(), 32)...)
+	}
+	tx := func(to common.Address, value int64, gas uint64, data []byte) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{To: &to, Value: big.NewInt(value), Gas: gas, GasPrice: big.NewInt(1), Data: data})
+	}
+
+	for _, tc := range []struct {
+		name      string
+		from      common.Address
+		tx        *types.Transaction
+		recipient common.Address
+		err       string
+	}{
+		{name: "NFT transfer", from: a.NFTHolder, tx: tx(a.NFTContract, 0, 80000, transferFrom(a.NFTHolder, winner, 7)), recipient: winner},
+		{name: "NFT holder calls another contract", from: a.NFTHolder, tx: tx(common.Address{0xc1}, 0, 80000, transferFrom(a.NFTHolder, winner, 7)), err: "not the NFT contract"},
+		{name: "NFT holder sends ETH", from: a.NFTHolder, tx: tx(a.NFTContract, 1, 80000, transferFrom(a.NFTHolder, winner, 7)), err: "value"},
+		{name: "other token", from: a.NFTHolder, tx: tx(a.NFTContract, 0, 80000, transferFrom(a.NFTHolder, winner, 8)), err: "token 8"},
+		{name: "NFT of another owner", from: a.NFTHolder, tx: tx(a.NFTContract, 0, 80000, transferFrom(bidder, winner, 7)), err: "moves the NFT of"},
+		{name: "other call", from: a.NFTHolder, tx: tx(a.NFTContract, 0, 80000, []byte{0x09, 0x5e, 0xa7, 0xb3}), err: "does not call transferFrom"},
+		{name: "ETH transfer", from: bidder, tx: tx(winner, 1000, ethTransferGas, nil), recipient: winner},
+		{name: "ETH transfer with data", from: bidder, tx: tx(winner, 1000, ethTransferGas, []byte{0x01}), err: "carries data"},
+		{name: "ETH transfer with more gas", from: bidder, tx: tx(winner, 1000, 50000, nil), err: "gas limit"},
+		{name: "ETH transfer without value", from: bidder, tx: tx(winner, 0, ethTransferGas, nil), err: "no value"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recipient, err := a.recipient(tc.from, tc.tx)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("error %v, expected %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if recipient != tc.recipient {
+				t.Fatalf("recipient %s, expected %s", recipient.Hex(), tc.recipient.Hex())
+			}
+		})
+	}
+}
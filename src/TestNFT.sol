@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Unlicense
+pragma solidity ^0.8.13;
+
+import "openzeppelin-contracts/contracts/token/ERC721/ERC721.sol";
+
+/**
+ * @notice ERC721 that anybody can mint, deployed by the driver to auction a token on a local L1.
+ */
+contract TestNFT is ERC721 {
+    uint256 public nextTokenId = 1;
+
+    constructor() ERC721("Sealed Auction Test NFT", "SATN") {}
+
+    function mint(address to) external returns (uint256 tokenId) {
+        tokenId = nextTokenId++;
+        _mint(to, tokenId);
+    }
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Unlicense
+pragma solidity ^0.8.13;
+
+import "../src/ProposerVersion/OracleProposer.sol";
+
+/**
+ * @notice OracleProposer that queries the given Alchemy and Etherscan compatible endpoints instead of the public ones.
+ * @dev Only for local runs and recordings, e.g. against cmd/standin or cmd/oracleproxy. The endpoints are fixed at
+ * @dev deployment, an auction must never trust an oracle whose endpoints its deployer chose.
+ */
+contract TestOracleProposer is OracleProposer {
+    constructor(
+        uint256 _chainID,
+        string memory alchemyURL,
+        string memory etherscanURL
+    ) OracleProposer(_chainID) {
+        BASE_ALCHEMY_URL = alchemyURL;
+        BASE_SEPOLIA_ETHERSCAN_URL = etherscanURL;
+    }
+}